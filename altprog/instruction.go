@@ -0,0 +1,125 @@
+package altprog
+
+import (
+	"encoding/binary"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+type Instruction uint32
+
+const (
+	InstructionCreateLookupTable Instruction = iota
+	InstructionFreezeLookupTable
+	InstructionExtendLookupTable
+	InstructionDeactivateLookupTable
+	InstructionCloseLookupTable
+)
+
+// FindLookupTableAddress derives the address of the lookup table created by authority at recentSlot
+func FindLookupTableAddress(authority common.PublicKey, recentSlot uint64) (common.PublicKey, uint8, error) {
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, recentSlot)
+	address, bump, err := common.FindProgramAddress([][]byte{authority.Bytes(), slot}, common.AddressLookupTableProgramID)
+	if err != nil {
+		return common.PublicKey{}, 0, err
+	}
+	return address, uint8(bump), nil
+}
+
+// CreateLookupTable returns the instruction and the address of the new lookup table,
+// recentSlot must be a recent slot of the cluster
+func CreateLookupTable(authority, payer common.PublicKey, recentSlot uint64) (types.Instruction, common.PublicKey) {
+	lookupTable, bump, err := FindLookupTableAddress(authority, recentSlot)
+	if err != nil {
+		panic(err)
+	}
+	data, err := common.SerializeData(struct {
+		Instruction Instruction
+		RecentSlot  uint64
+		BumpSeed    uint8
+	}{
+		Instruction: InstructionCreateLookupTable,
+		RecentSlot:  recentSlot,
+		BumpSeed:    bump,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+			{PubKey: payer, IsSigner: true, IsWritable: true},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		},
+		ProgramID: common.AddressLookupTableProgramID,
+		Data:      data,
+	}, lookupTable
+}
+
+func FreezeLookupTable(lookupTable, authority common.PublicKey) types.Instruction {
+	return authorityInstruction(InstructionFreezeLookupTable, lookupTable, authority)
+}
+
+// ExtendLookupTable appends addresses to the lookup table, payer funds the extra rent and
+// may be the zero public key if the table already holds enough lamports
+func ExtendLookupTable(lookupTable, authority, payer common.PublicKey, addresses []common.PublicKey) types.Instruction {
+	// the address list is a bincode vec with a u64 length prefix
+	data := make([]byte, 12, 12+len(addresses)*32)
+	binary.LittleEndian.PutUint32(data[:4], uint32(InstructionExtendLookupTable))
+	binary.LittleEndian.PutUint64(data[4:12], uint64(len(addresses)))
+	for _, address := range addresses {
+		data = append(data, address.Bytes()...)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+		{PubKey: authority, IsSigner: true, IsWritable: false},
+	}
+	if payer != (common.PublicKey{}) {
+		accounts = append(accounts,
+			types.AccountMeta{PubKey: payer, IsSigner: true, IsWritable: true},
+			types.AccountMeta{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		)
+	}
+
+	return types.Instruction{
+		Accounts:  accounts,
+		ProgramID: common.AddressLookupTableProgramID,
+		Data:      data,
+	}
+}
+
+func DeactivateLookupTable(lookupTable, authority common.PublicKey) types.Instruction {
+	return authorityInstruction(InstructionDeactivateLookupTable, lookupTable, authority)
+}
+
+// CloseLookupTable closes a deactivated lookup table and sends its lamports to recipient
+func CloseLookupTable(lookupTable, authority, recipient common.PublicKey) types.Instruction {
+	instruction := authorityInstruction(InstructionCloseLookupTable, lookupTable, authority)
+	instruction.Accounts = append(instruction.Accounts, types.AccountMeta{PubKey: recipient, IsSigner: false, IsWritable: true})
+	return instruction
+}
+
+func authorityInstruction(instruction Instruction, lookupTable, authority common.PublicKey) types.Instruction {
+	data, err := common.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: instruction,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+		},
+		ProgramID: common.AddressLookupTableProgramID,
+		Data:      data,
+	}
+}
@@ -0,0 +1,97 @@
+package altprog
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+var (
+	testAuthority = common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	testPayer     = common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+)
+
+func TestCreateLookupTable(t *testing.T) {
+	instruction, lookupTable := CreateLookupTable(testAuthority, testPayer, 123456)
+
+	address, bump, err := FindLookupTableAddress(testAuthority, 123456)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != lookupTable {
+		t.Fatalf("lookup table = %s, want %s", lookupTable.ToBase58(), address.ToBase58())
+	}
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, 123456)
+	derived, err := common.CreateProgramAddress([][]byte{testAuthority.Bytes(), slot, {bump}}, common.AddressLookupTableProgramID)
+	if err != nil || derived != address {
+		t.Fatalf("address is not derived from authority and slot, err %v", err)
+	}
+
+	wantData := append([]byte{0, 0, 0, 0}, slot...)
+	wantData = append(wantData, bump)
+	want := types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: testAuthority, IsSigner: true, IsWritable: false},
+			{PubKey: testPayer, IsSigner: true, IsWritable: true},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		},
+		Data: wantData,
+	}
+	if !reflect.DeepEqual(instruction, want) {
+		t.Fatalf("CreateLookupTable() = %+v, want %+v", instruction, want)
+	}
+}
+
+func TestExtendLookupTable(t *testing.T) {
+	lookupTable := common.PublicKeyFromString("8Vaso6eE1pWktDHwy2qQBB1fhjmBgwzhoXQKe1sxtFjn")
+	addresses := []common.PublicKey{common.SystemProgramID, common.StakeProgramID}
+
+	instruction := ExtendLookupTable(lookupTable, testAuthority, testPayer, addresses)
+	wantData := []byte{2, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}
+	wantData = append(wantData, common.SystemProgramID.Bytes()...)
+	wantData = append(wantData, common.StakeProgramID.Bytes()...)
+	if !reflect.DeepEqual(instruction.Data, wantData) {
+		t.Fatalf("data = %v, want %v", instruction.Data, wantData)
+	}
+	if len(instruction.Accounts) != 4 {
+		t.Fatalf("accounts = %d, want 4", len(instruction.Accounts))
+	}
+
+	instruction = ExtendLookupTable(lookupTable, testAuthority, common.PublicKey{}, addresses)
+	if len(instruction.Accounts) != 2 {
+		t.Fatalf("accounts without payer = %d, want 2", len(instruction.Accounts))
+	}
+}
+
+func TestAuthorityInstructions(t *testing.T) {
+	lookupTable := common.PublicKeyFromString("8Vaso6eE1pWktDHwy2qQBB1fhjmBgwzhoXQKe1sxtFjn")
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		wantData    []byte
+		wantLen     int
+	}{
+		{"freeze", FreezeLookupTable(lookupTable, testAuthority), []byte{1, 0, 0, 0}, 2},
+		{"deactivate", DeactivateLookupTable(lookupTable, testAuthority), []byte{3, 0, 0, 0}, 2},
+		{"close", CloseLookupTable(lookupTable, testAuthority, testPayer), []byte{4, 0, 0, 0}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.instruction.Data, tt.wantData) {
+				t.Errorf("data = %v, want %v", tt.instruction.Data, tt.wantData)
+			}
+			if len(tt.instruction.Accounts) != tt.wantLen {
+				t.Errorf("accounts = %d, want %d", len(tt.instruction.Accounts), tt.wantLen)
+			}
+			if !tt.instruction.Accounts[1].IsSigner || tt.instruction.Accounts[1].PubKey != testAuthority {
+				t.Errorf("authority must sign")
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package altprog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+// LookupTableMetaSize is the size of the lookup table header, the addresses follow it
+const LookupTableMetaSize = 56
+
+// MaxLookupTableAddresses is the most addresses a lookup table can hold
+const MaxLookupTableAddresses = 256
+
+const lookupTableStateLookupTable = 1
+
+type LookupTable struct {
+	DeactivationSlot           uint64 // math.MaxUint64 while the table is active
+	LastExtendedSlot           uint64
+	LastExtendedSlotStartIndex uint8
+	Authority                  *common.PublicKey // nil once the table is frozen
+	Addresses                  []common.PublicKey
+}
+
+func LookupTableDeserialize(data []byte) (LookupTable, error) {
+	if len(data) < LookupTableMetaSize {
+		return LookupTable{}, fmt.Errorf("lookup table data size is not enough")
+	}
+	if state := binary.LittleEndian.Uint32(data[:4]); state != lookupTableStateLookupTable {
+		return LookupTable{}, fmt.Errorf("lookup table state %d is not initialized", state)
+	}
+	if (len(data)-LookupTableMetaSize)%32 != 0 {
+		return LookupTable{}, fmt.Errorf("lookup table addresses size %d is not a multiple of 32", len(data)-LookupTableMetaSize)
+	}
+
+	var authority *common.PublicKey
+	if data[21] == 1 {
+		key := common.PublicKeyFromBytes(data[22:54])
+		authority = &key
+	}
+	addressData := data[LookupTableMetaSize:]
+	addresses := make([]common.PublicKey, 0, len(addressData)/32)
+	for i := 0; i < len(addressData); i += 32 {
+		addresses = append(addresses, common.PublicKeyFromBytes(addressData[i:i+32]))
+	}
+
+	return LookupTable{
+		DeactivationSlot:           binary.LittleEndian.Uint64(data[4:12]),
+		LastExtendedSlot:           binary.LittleEndian.Uint64(data[12:20]),
+		LastExtendedSlotStartIndex: data[20],
+		Authority:                  authority,
+		Addresses:                  addresses,
+	}, nil
+}
+
+// IsActive reports whether the table has not been deactivated
+func (t LookupTable) IsActive() bool {
+	return t.DeactivationSlot == math.MaxUint64
+}
+
+// ToAddressLookupTableAccount pairs the table with its address for compiling v0 messages
+func (t LookupTable) ToAddressLookupTableAccount(key common.PublicKey) types.AddressLookupTableAccount {
+	return types.AddressLookupTableAccount{
+		Key:       key,
+		Addresses: t.Addresses,
+	}
+}
@@ -0,0 +1,90 @@
+package altprog
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+func lookupTableData(deactivationSlot uint64, authority *common.PublicKey, addresses []common.PublicKey) []byte {
+	data := make([]byte, LookupTableMetaSize)
+	binary.LittleEndian.PutUint32(data[:4], 1)
+	binary.LittleEndian.PutUint64(data[4:12], deactivationSlot)
+	binary.LittleEndian.PutUint64(data[12:20], 100)
+	data[20] = 1
+	if authority != nil {
+		data[21] = 1
+		copy(data[22:54], authority.Bytes())
+	}
+	for _, address := range addresses {
+		data = append(data, address.Bytes()...)
+	}
+	return data
+}
+
+func TestLookupTableDeserialize(t *testing.T) {
+	addresses := []common.PublicKey{common.SystemProgramID, common.StakeProgramID}
+	tests := []struct {
+		name    string
+		data    []byte
+		want    LookupTable
+		wantErr bool
+	}{
+		{
+			name: "active",
+			data: lookupTableData(math.MaxUint64, &testAuthority, addresses),
+			want: LookupTable{
+				DeactivationSlot:           math.MaxUint64,
+				LastExtendedSlot:           100,
+				LastExtendedSlotStartIndex: 1,
+				Authority:                  &testAuthority,
+				Addresses:                  addresses,
+			},
+		},
+		{
+			name: "frozen",
+			data: lookupTableData(math.MaxUint64, nil, addresses),
+			want: LookupTable{
+				DeactivationSlot:           math.MaxUint64,
+				LastExtendedSlot:           100,
+				LastExtendedSlotStartIndex: 1,
+				Addresses:                  addresses,
+			},
+		},
+		{
+			name:    "short",
+			data:    make([]byte, LookupTableMetaSize-1),
+			wantErr: true,
+		},
+		{
+			name:    "uninitialized",
+			data:    make([]byte, LookupTableMetaSize),
+			wantErr: true,
+		},
+		{
+			name:    "partial address",
+			data:    lookupTableData(math.MaxUint64, nil, addresses)[:LookupTableMetaSize+40],
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LookupTableDeserialize(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupTableDeserialize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("LookupTableDeserialize() = %+v, want %+v", got, tt.want)
+			}
+			if !got.IsActive() {
+				t.Fatal("table should be active")
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/mr-tron/base58"
+	"github.com/near/borsh-go"
+)
+
+// zstdDecoder is safe for concurrent DecodeAll calls
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+// ParsedAccountData is the data of an account fetched with jsonParsed encoding
+type ParsedAccountData struct {
+	Program string          `json:"program"`
+	Parsed  json.RawMessage `json:"parsed"`
+	Space   uint64          `json:"space"`
+}
+
+// AccountData is the data field of an account, decoded whatever the requested encoding
+type AccountData struct {
+	raw      json.RawMessage
+	encoding GetAccountInfoConfigEncoding
+	data     []byte
+	parsed   *ParsedAccountData
+}
+
+func (d *AccountData) UnmarshalJSON(b []byte) error {
+	*d = AccountData{raw: append(json.RawMessage{}, b...)}
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	switch b[0] {
+	case '{':
+		parsed := ParsedAccountData{}
+		if err := json.Unmarshal(b, &parsed); err != nil {
+			return fmt.Errorf("account parsed data err: %w", err)
+		}
+		d.encoding = GetAccountInfoConfigEncodingJsonParsed
+		d.parsed = &parsed
+		return nil
+	case '"':
+		// the legacy binary encoding is a bare base58 string
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		return d.decode(s, GetAccountInfoConfigEncodingBase58)
+	case '[':
+		var pair []string
+		if err := json.Unmarshal(b, &pair); err != nil {
+			return fmt.Errorf("account data err: %w", err)
+		}
+		if len(pair) != 2 {
+			return fmt.Errorf("account data length err")
+		}
+		return d.decode(pair[0], GetAccountInfoConfigEncoding(pair[1]))
+	default:
+		return fmt.Errorf("account data err")
+	}
+}
+
+func (d *AccountData) decode(s string, encoding GetAccountInfoConfigEncoding) error {
+	var data []byte
+	var err error
+	switch encoding {
+	case GetAccountInfoConfigEncodingBase58:
+		data, err = base58.Decode(s)
+	case GetAccountInfoConfigEncodingBase64:
+		data, err = base64.StdEncoding.DecodeString(s)
+	case GetAccountInfoConfigEncodingBase64Zstd:
+		data, err = base64.StdEncoding.DecodeString(s)
+		if err == nil {
+			data, err = zstdDecoder.DecodeAll(data, nil)
+		}
+	default:
+		return fmt.Errorf("account data encoding %s not supported", encoding)
+	}
+	if err != nil {
+		return fmt.Errorf("account data %s decode err: %w", encoding, err)
+	}
+	d.encoding = encoding
+	d.data = data
+	return nil
+}
+
+// MarshalJSON returns the data as it was received
+func (d AccountData) MarshalJSON() ([]byte, error) {
+	if len(d.raw) == 0 {
+		return []byte("null"), nil
+	}
+	return d.raw, nil
+}
+
+// Encoding returns the encoding of the response, jsonParsed requests may get base64 back
+func (d AccountData) Encoding() GetAccountInfoConfigEncoding {
+	return d.encoding
+}
+
+// Bytes returns the raw account data, nil if the data was returned parsed
+func (d AccountData) Bytes() []byte {
+	return d.data
+}
+
+// Parsed returns the data of an account fetched with jsonParsed encoding, nil if
+// the node returned the raw data
+func (d AccountData) Parsed() *ParsedAccountData {
+	return d.parsed
+}
+
+// accountDataBytes returns the raw data of an account fetched with a binary encoding
+func accountDataBytes(accountInfo GetAccountInfoResponse) ([]byte, error) {
+	if accountInfo.Data.Parsed() != nil {
+		return nil, fmt.Errorf("account data is parsed by %s, want binary", accountInfo.Data.Parsed().Program)
+	}
+	return accountInfo.Data.Bytes(), nil
+}
+
+// AccountDecoder turns the raw data of an account into T
+type AccountDecoder[T any] func(data []byte) (*T, error)
+
+// BorshDecoder borsh deserializes the whole account data
+func BorshDecoder[T any](data []byte) (*T, error) {
+	var t T
+	if err := borsh.Deserialize(&t, data); err != nil {
+		return nil, fmt.Errorf("deserialize err: %s", err.Error())
+	}
+	return &t, nil
+}
+
+// AnchorDecoder borsh deserializes the data after the 8 bytes anchor discriminator
+func AnchorDecoder[T any](data []byte) (*T, error) {
+	if len(data) <= 8 {
+		return nil, errors.New("no account data bytes")
+	}
+	return BorshDecoder[T](data[8:])
+}
+
+// AccountInfoGetter is served by Client and QuorumClient
+type AccountInfoGetter interface {
+	GetAccountInfo(ctx context.Context, account string, cfg GetAccountInfoConfig) (GetAccountInfoResponse, error)
+}
+
+// GetAccountAs fetches the account with cfg and decodes its data with decode, e.g.
+// GetAccountAs(ctx, c, addr, GetUnstakeAccountCfgDefault, AnchorDecoder[rsolprog.UnstakeAccount])
+func GetAccountAs[T any](ctx context.Context, getter AccountInfoGetter, account string, cfg GetAccountInfoConfig, decode AccountDecoder[T]) (*T, error) {
+	accountInfo, err := getter.GetAccountInfo(ctx, account, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAccountWith(decode)(accountInfo)
+}
+
+// decodeAccountWith adapts decode to the account info decoders taken by getMultipleDecoded
+func decodeAccountWith[T any](decode AccountDecoder[T]) func(GetAccountInfoResponse) (*T, error) {
+	return func(accountInfo GetAccountInfoResponse) (*T, error) {
+		data, err := accountDataBytes(accountInfo)
+		if err != nil {
+			return nil, err
+		}
+		return decode(data)
+	}
+}
@@ -0,0 +1,92 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func TestAccountDataUnmarshalJSON(t *testing.T) {
+	want := "hello solana account"
+	tests := []struct {
+		name     string
+		data     string
+		encoding client.GetAccountInfoConfigEncoding
+	}{
+		{name: "base58", data: `["` + base58.Encode([]byte(want)) + `","base58"]`, encoding: client.GetAccountInfoConfigEncodingBase58},
+		{name: "legacy binary", data: `"` + base58.Encode([]byte(want)) + `"`, encoding: client.GetAccountInfoConfigEncodingBase58},
+		{name: "base64", data: `["aGVsbG8gc29sYW5hIGFjY291bnQ=","base64"]`, encoding: client.GetAccountInfoConfigEncodingBase64},
+		{name: "base64+zstd", data: `["KLUv/QQAoQAAaGVsbG8gc29sYW5hIGFjY291bnQufYm2","base64+zstd"]`, encoding: client.GetAccountInfoConfigEncodingBase64Zstd},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := client.AccountData{}
+			if err := json.Unmarshal([]byte(tt.data), &data); err != nil {
+				t.Fatal(err)
+			}
+			if string(data.Bytes()) != want {
+				t.Fatalf("bytes = %q, want %q", data.Bytes(), want)
+			}
+			if data.Encoding() != tt.encoding {
+				t.Fatalf("encoding = %s, want %s", data.Encoding(), tt.encoding)
+			}
+			if data.Parsed() != nil {
+				t.Fatal("parsed must be nil")
+			}
+			raw, err := json.Marshal(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(raw) != tt.data {
+				t.Fatalf("marshal = %s, want %s", raw, tt.data)
+			}
+		})
+	}
+
+	data := client.AccountData{}
+	err := json.Unmarshal([]byte(`{"program":"spl-token","parsed":{"type":"account","info":{"mint":"x"}},"space":165}`), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Bytes() != nil || data.Parsed() == nil || data.Parsed().Program != "spl-token" || data.Parsed().Space != 165 {
+		t.Fatalf("parsed = %+v", data.Parsed())
+	}
+
+	for _, bad := range []string{`["abc"]`, `["abc","base32"]`, `["!!","base64"]`, `1`} {
+		if err := json.Unmarshal([]byte(bad), &data); err == nil {
+			t.Fatalf("%s want err", bad)
+		}
+	}
+}
+
+type anchorCounter struct {
+	Count uint64
+}
+
+func TestGetAccountAs(t *testing.T) {
+	// 8 bytes discriminator followed by the borsh u64 7
+	c, _ := newFakeClient(t, map[string]string{
+		"getAccountInfo": `{"context":{"slot":1},"value":{"lamports":1,"owner":"11111111111111111111111111111111","executable":false,"rentEpoch":0,"data":["AQIDBAUGBwgHAAAAAAAAAA==","base64"]}}`,
+	})
+	cfg := client.GetAccountInfoConfig{Encoding: client.GetAccountInfoConfigEncodingBase64}
+
+	counter, err := client.GetAccountAs(context.Background(), c, "11111111111111111111111111111111", cfg, client.AnchorDecoder[anchorCounter])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counter.Count != 7 {
+		t.Fatalf("count = %d, want 7", counter.Count)
+	}
+
+	decodeErr := errors.New("decode err")
+	_, err = client.GetAccountAs(context.Background(), c, "11111111111111111111111111111111", cfg, func(data []byte) (*anchorCounter, error) {
+		return nil, decodeErr
+	})
+	if !errors.Is(err, decodeErr) {
+		t.Fatalf("err = %v, want the decoder's err", err)
+	}
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Batch collects calls that are sent together as one json rpc batch request
+//
+//	results, err := c.Batch().GetAccountInfo(a, cfg).GetBalance(b).Do(ctx)
+type Batch struct {
+	client *Client
+	calls  []batchCall
+}
+
+type batchCall struct {
+	method string
+	params []interface{}
+	decode func(result json.RawMessage) (interface{}, error)
+}
+
+// BatchResult is the outcome of one call in a batch, Value holds the same type the
+// corresponding Client method returns
+type BatchResult struct {
+	Method string
+	Value  interface{}
+	Raw    json.RawMessage
+	Err    error
+}
+
+func (s *Client) Batch() *Batch {
+	return &Batch{client: s}
+}
+
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Call adds a raw call, its Value is the undecoded result
+func (b *Batch) Call(method string, params ...interface{}) *Batch {
+	if params == nil {
+		params = []interface{}{}
+	}
+	return b.add(method, params, func(result json.RawMessage) (interface{}, error) {
+		return result, nil
+	})
+}
+
+func (b *Batch) GetAccountInfo(account string, cfg GetAccountInfoConfig) *Batch {
+	return b.add("getAccountInfo", []interface{}{account, cfg}, func(result json.RawMessage) (interface{}, error) {
+		return decodeBatchAccountInfo(result)
+	})
+}
+
+// GetStakeAccountInfo adds a getAccountInfo call whose Value is a *StakeAccountRsp
+func (b *Batch) GetStakeAccountInfo(account string) *Batch {
+	return b.add("getAccountInfo", []interface{}{account, GetStakeAccountInfoConfigDefault}, func(result json.RawMessage) (interface{}, error) {
+		accountInfo, err := decodeBatchAccountInfo(result)
+		if err != nil {
+			return nil, err
+		}
+		return decodeStakeAccount(accountInfo)
+	})
+}
+
+func (b *Batch) GetBalance(base58Addr string) *Batch {
+	return b.add("getBalance", []interface{}{base58Addr}, func(result json.RawMessage) (interface{}, error) {
+		res := struct {
+			Context Context `json:"context"`
+			Value   uint64  `json:"value"`
+		}{}
+		err := json.Unmarshal(result, &res)
+		return res.Value, err
+	})
+}
+
+func (b *Batch) GetSlot(cfg GetSlotConfig) *Batch {
+	return b.add("getSlot", []interface{}{cfg}, func(result json.RawMessage) (interface{}, error) {
+		var slot uint64
+		err := json.Unmarshal(result, &slot)
+		return slot, err
+	})
+}
+
+func (b *Batch) GetBlockHeight(cfg GetBlockHeightConfig) *Batch {
+	return b.add("getBlockHeight", []interface{}{cfg}, func(result json.RawMessage) (interface{}, error) {
+		var height uint64
+		err := json.Unmarshal(result, &height)
+		return height, err
+	})
+}
+
+func (b *Batch) GetSignatureStatuses(signatures []string) *Batch {
+	return b.add("getSignatureStatuses", []interface{}{signatures, map[string]interface{}{"searchTransactionHistory": true}}, func(result json.RawMessage) (interface{}, error) {
+		res := struct {
+			Context Context                        `json:"context"`
+			Value   []GetSignatureStatusesResponse `json:"value"`
+		}{}
+		err := json.Unmarshal(result, &res)
+		return res.Value, err
+	})
+}
+
+func (b *Batch) add(method string, params []interface{}, decode func(json.RawMessage) (interface{}, error)) *Batch {
+	b.calls = append(b.calls, batchCall{method: method, params: params, decode: decode})
+	return b
+}
+
+func decodeBatchAccountInfo(result json.RawMessage) (GetAccountInfoResponse, error) {
+	res := struct {
+		Context Context                 `json:"context"`
+		Value   *GetAccountInfoResponse `json:"value"`
+	}{}
+	if err := json.Unmarshal(result, &res); err != nil {
+		return GetAccountInfoResponse{}, err
+	}
+	if res.Value == nil {
+		return GetAccountInfoResponse{}, ErrAccountNotFound
+	}
+	return *res.Value, nil
+}
+
+// Do sends the batch. Failures of the whole request are retried like a single call and
+// returned as err, failures of a single call are returned in its BatchResult.
+// Results are in the order the calls were added.
+func (b *Batch) Do(ctx context.Context) ([]BatchResult, error) {
+	if len(b.calls) == 0 {
+		return nil, nil
+	}
+	reqs := make([]map[string]interface{}, 0, len(b.calls))
+	for i, call := range b.calls {
+		reqs = append(reqs, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      i,
+			"method":  call.method,
+			"params":  call.params,
+		})
+	}
+	payload, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []batchResponse
+	err = b.client.send(ctx, payload, func(body []byte) error {
+		responses, err = decodeBatchResponse(body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(b.calls))
+	found := make([]bool, len(b.calls))
+	for _, res := range responses {
+		if res.ID < 0 || res.ID >= len(b.calls) || found[res.ID] {
+			continue
+		}
+		found[res.ID] = true
+		call := b.calls[res.ID]
+		result := BatchResult{Method: call.method, Raw: res.Result}
+		if res.Error != nil {
+			result.Err = newRPCError(*res.Error)
+		} else {
+			result.Value, result.Err = call.decode(res.Result)
+		}
+		results[res.ID] = result
+	}
+	for i, ok := range found {
+		if !ok {
+			results[i] = BatchResult{Method: b.calls[i].method, Err: fmt.Errorf("no response for batch id %d", i)}
+		}
+	}
+	return results, nil
+}
+
+type batchResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *ErrorResponse  `json:"error"`
+}
+
+// decodeBatchResponse accepts the response array, or the single error object a server
+// returns when it rejects the whole batch
+func decodeBatchResponse(body []byte) ([]batchResponse, error) {
+	responses := []batchResponse{}
+	if err := json.Unmarshal(body, &responses); err == nil {
+		return responses, nil
+	}
+	generayRes := GeneralResponse{}
+	if err := json.Unmarshal(body, &generayRes); err != nil {
+		return nil, err
+	}
+	if generayRes.Error != nil {
+		return nil, newRPCError(*generayRes.Error)
+	}
+	return nil, fmt.Errorf("batch response format err")
+}
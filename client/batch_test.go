@@ -0,0 +1,66 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func TestBatch(t *testing.T) {
+	calls := 0
+	fake := client.RPCTransportFunc(func(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+		calls++
+		reqs := []struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}{}
+		if err := json.Unmarshal(payload, &reqs); err != nil {
+			return nil, err
+		}
+		res := make([]string, 0, len(reqs))
+		// answer in reverse order, results must be matched by id
+		for i := len(reqs) - 1; i >= 0; i-- {
+			req := reqs[i]
+			switch req.Method {
+			case "getBalance":
+				res = append(res, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"context":{"slot":1},"value":9}}`, req.ID))
+			case "getAccountInfo":
+				res = append(res, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"context":{"slot":1},"value":null}}`, req.ID))
+			default:
+				res = append(res, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"Method not found"}}`, req.ID))
+			}
+		}
+		return []byte("[" + strings.Join(res, ",") + "]"), nil
+	})
+	c := client.NewClient([]string{"fake"}, client.WithTransport(fake))
+
+	results, err := c.Batch().
+		GetBalance("11111111111111111111111111111111").
+		GetAccountInfo("11111111111111111111111111111111", client.GetAccountInfoConfig{}).
+		Call("getFoo").
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+	if len(results) != 3 {
+		t.Fatalf("results = %d, want 3", len(results))
+	}
+	if results[0].Err != nil || results[0].Value.(uint64) != 9 {
+		t.Fatalf("balance result = %+v", results[0])
+	}
+	if !errors.Is(results[1].Err, client.ErrAccountNotFound) {
+		t.Fatalf("account result = %+v", results[1])
+	}
+	var rpcErr *client.RPCError
+	if !errors.As(results[2].Err, &rpcErr) || rpcErr.Code != client.RPCCodeMethodNotFound {
+		t.Fatalf("raw call result = %+v", results[2])
+	}
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+
+	"github.com/stafiprotocol/solana-go-sdk/altprog"
+	"github.com/stafiprotocol/solana-go-sdk/lsdprog"
+	"github.com/stafiprotocol/solana-go-sdk/minterprog"
+	"github.com/stafiprotocol/solana-go-sdk/rsolprog"
+	"github.com/stafiprotocol/solana-go-sdk/sysprog"
+	"github.com/stafiprotocol/solana-go-sdk/tokenprog"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+// RPCCaller is the set of rpc methods served by Client, depend on it instead of *Client
+// so that callers can be tested against a fake
+type RPCCaller interface {
+	GetAccountInfo(ctx context.Context, account string, cfg GetAccountInfoConfig) (GetAccountInfoResponse, error)
+	GetAccountInfoAndContext(ctx context.Context, account string, cfg GetAccountInfoConfig) (ValueWithContext[*GetAccountInfoResponse], error)
+	GetBalance(ctx context.Context, base58Addr string) (uint64, error)
+	GetBalanceWithConfig(ctx context.Context, base58Addr string, cfg GetBalanceConfig) (uint64, error)
+	GetBalanceAndContext(ctx context.Context, base58Addr string, cfg GetBalanceConfig) (ValueWithContext[uint64], error)
+	GetBlock(ctx context.Context, slot uint64, cfg GetBlockConfig) (GetBlockResponse, error)
+	GetBlockHeight(ctx context.Context, cfg GetBlockHeightConfig) (uint64, error)
+	GetBlockTime(ctx context.Context, slot uint64) (uint64, error)
+	GetClusterNodes(ctx context.Context) ([]ClusterNode, error)
+	GetConfirmedBlocksWithLimit(ctx context.Context, startSlot uint64, limit uint64) ([]uint64, error)
+	GetEpochInfo(ctx context.Context, commitment Commitment) (GetEpochInfoResponse, error)
+	GetEpochInfoWithConfig(ctx context.Context, cfg GetEpochInfoConfig) (GetEpochInfoResponse, error)
+	GetEpochSchedule(ctx context.Context) (EpochSchedule, error)
+	GetInflationGovernor(ctx context.Context, cfg GetInflationGovernorConfig) (InflationGovernor, error)
+	GetInflationRate(ctx context.Context) (InflationRate, error)
+	GetInflationReward(ctx context.Context, addresses []string, cfg GetInflationRewardConfig) ([]*InflationReward, error)
+	GetLatestBlockhash(ctx context.Context, cfg GetLatestBlockhashConfig) (GetLatestBlockHashResponse, error)
+	GetLatestBlockhashAndContext(ctx context.Context, cfg GetLatestBlockhashConfig) (ValueWithContext[GetLatestBlockHashResponse], error)
+	GetLeaderSchedule(ctx context.Context, slot *uint64, cfg GetLeaderScheduleConfig) (map[string][]uint64, error)
+	GetMinimumBalanceForRentExemption(ctx context.Context, accountDataLen uint64) (uint64, error)
+	GetMinimumBalanceForRentExemptionWithConfig(ctx context.Context, accountDataLen uint64, cfg GetMinimumBalanceForRentExemptionConfig) (uint64, error)
+	GetMinDelegationAmount(ctx context.Context) (uint64, error)
+	GetMinDelegationAmountAndContext(ctx context.Context, cfg GetMinDelegationAmountConfig) (ValueWithContext[uint64], error)
+	GetMultipleAccounts(ctx context.Context, accounts []string, cfg GetAccountInfoConfig) ([]*GetAccountInfoResponse, error)
+	GetProgramAccounts(ctx context.Context, programId string, cfg GetProgramAccountsConfig) ([]GetProgramAccountsResponse, error)
+	GetProgramAccountsAndContext(ctx context.Context, programId string, cfg GetProgramAccountsConfig) (ValueWithContext[[]GetProgramAccountsResponse], error)
+	GetRecentPrioritizationFees(ctx context.Context, addresses []string) ([]GetRecentPrioritizationFeesResponse, error)
+	GetSignaturesForAddress(ctx context.Context, base58Addr string, config GetSignaturesForAddressConfig) ([]GetSignaturesForAddress, error)
+	GetSignatureStatuses(ctx context.Context, signatures []string) ([]GetSignatureStatusesResponse, error)
+	GetSignatureStatusesAndContext(ctx context.Context, signatures []string) (ValueWithContext[[]GetSignatureStatusesResponse], error)
+	GetSlot(ctx context.Context, cfg GetSlotConfig) (uint64, error)
+	GetStakeActivation(ctx context.Context, address string, cfg GetStakeActivationConfig) (GetStakeActivationResponse, error)
+	GetTokenAccountBalance(ctx context.Context, account string, cfg GetTokenConfig) (TokenAmount, error)
+	GetTokenAccountBalanceAndContext(ctx context.Context, account string, cfg GetTokenConfig) (ValueWithContext[TokenAmount], error)
+	GetTokenAccountsByDelegate(ctx context.Context, delegate string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) ([]GetTokenAccountsResponse, error)
+	GetTokenAccountsByDelegateAndContext(ctx context.Context, delegate string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) (ValueWithContext[[]GetTokenAccountsResponse], error)
+	GetTokenAccountsByOwner(ctx context.Context, owner string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) ([]GetTokenAccountsResponse, error)
+	GetTokenAccountsByOwnerAndContext(ctx context.Context, owner string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) (ValueWithContext[[]GetTokenAccountsResponse], error)
+	GetTokenLargestAccounts(ctx context.Context, mint string, cfg GetTokenConfig) ([]TokenLargestAccount, error)
+	GetTokenLargestAccountsAndContext(ctx context.Context, mint string, cfg GetTokenConfig) (ValueWithContext[[]TokenLargestAccount], error)
+	GetTokenSupply(ctx context.Context, mint string, cfg GetTokenConfig) (TokenAmount, error)
+	GetTokenSupplyAndContext(ctx context.Context, mint string, cfg GetTokenConfig) (ValueWithContext[TokenAmount], error)
+	GetTransaction(ctx context.Context, txhash string, cfg GetTransactionWithLimitConfig) (GetTransactionResponse, error)
+	GetTransactionV2(ctx context.Context, txhash string) (GetTransactionResponse, error)
+	GetVersion(ctx context.Context) (GetVersionResponse, error)
+	GetVoteAccounts(ctx context.Context, cfg GetVoteAccountsConfig) (GetVoteAccountsResponse, error)
+	RequestAirdrop(ctx context.Context, base58Addr string, lamport uint64) (string, error)
+	SendRawTransaction(ctx context.Context, tx []byte) (string, error)
+	SendAndConfirm(ctx context.Context, tx []byte, cfg SendAndConfirmConfig) (string, error)
+	SendTransaction(ctx context.Context, tx string, cfg SendTransactionConfig) (string, error)
+	SimulateTransaction(ctx context.Context, rawTx string, cfg SimulateTransactionConfig) (SimulateTransactionResponse, error)
+	SimulateTransactionAndContext(ctx context.Context, rawTx string, cfg SimulateTransactionConfig) (ValueWithContext[SimulateTransactionResponse], error)
+
+	EstimatePriorityFee(ctx context.Context, message types.Message, cfg EstimatePriorityFeeConfig) (uint64, error)
+
+	// account helpers
+	GetAddressLookupTable(ctx context.Context, account string) (*altprog.LookupTable, error)
+	CalStakeActivation(ctx context.Context, address string) (*GetStakeActivationResponse, error)
+	GetBridgeAccountInfo(ctx context.Context, account string) (*GetBridgeAccountInfo, error)
+	GetLsdStack(ctx context.Context, account string) (*lsdprog.Stack, error)
+	GetLsdStackFeeAccount(ctx context.Context, account string) (*lsdprog.StackFeeAccount, error)
+	GetLsdStakeManager(ctx context.Context, account string) (*lsdprog.StakeManager, error)
+	GetMintManager(ctx context.Context, account string) (*minterprog.MintManager, error)
+	GetMintProposalInfo(ctx context.Context, account string) (*GetMintProposalINfo, error)
+	GetMultisigInfoAccountInfo(ctx context.Context, account string) (*GetMultisigInfoAccountInfo, error)
+	GetMultisigTxAccountInfo(ctx context.Context, account string) (*GetMultisigTxAccountInfo, error)
+	GetNonceAccount(ctx context.Context, account string) (*sysprog.NonceAccount, error)
+	GetMultipleStakeAccounts(ctx context.Context, accounts []string) ([]*StakeAccountRsp, error)
+	GetMultipleTokenAccounts(ctx context.Context, accounts []string) ([]*tokenprog.TokenAccount, error)
+	GetMultipleLsdStakeManagers(ctx context.Context, accounts []string) ([]*lsdprog.StakeManager, error)
+	GetMultipleLsdUnstakeAccounts(ctx context.Context, accounts []string) ([]*lsdprog.UnstakeAccount, error)
+	GetMultipleStakeManagers(ctx context.Context, accounts []string) ([]*rsolprog.StakeManager, error)
+	GetMultipleUnstakeAccounts(ctx context.Context, accounts []string) ([]*rsolprog.UnstakeAccount, error)
+	GetStakeAccountInfo(ctx context.Context, account string) (*StakeAccountRsp, error)
+	GetStakeHistory(ctx context.Context) (*StakeHistoryRsp, error)
+	GetStakeManager(ctx context.Context, account string) (*rsolprog.StakeManager, error)
+	GetTokenAccountInfo(ctx context.Context, account string) (*tokenprog.TokenAccount, error)
+	GetUnstakeAccount(ctx context.Context, programId string, stakeManager string, recipient string) ([]rsolprog.UnstakeAccount, error)
+	GetUnstakeAccountByEpoch(ctx context.Context, programId string, epoch uint64) ([]rsolprog.UnstakeAccount, error)
+	GetAddrRelateTxAfterSlot(addresses []string, dealtSlot uint64) ([]*SolTx, error)
+}
+
+var _ RPCCaller = (*Client)(nil)
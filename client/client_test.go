@@ -250,7 +250,7 @@ func TestGetMintProposalInfo(t *testing.T) {
 }
 
 func TestGetBlock(t *testing.T) {
-	height, err := c.GetBlockHeight(context.Background(), client.GetBlockHeightConfig{client.CommitmentFinalized})
+	height, err := c.GetBlockHeight(context.Background(), client.GetBlockHeightConfig{Commitment: client.CommitmentFinalized})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -315,12 +315,12 @@ func TestGetTransaction(t *testing.T) {
 	}
 	t.Logf("%+v", info3.Meta)
 	t.Log(info3.Meta.Err)
-	// blockHeight, err := c.GetBlockHeight(context.Background(), client.GetBlockHeightConfig{client.CommitmentFinalized})
+	// blockHeight, err := c.GetBlockHeight(context.Background(), client.GetBlockHeightConfig{Commitment: client.CommitmentFinalized})
 	// if err != nil {
 	// 	t.Fatal(err)
 	// }
 	// t.Log(blockHeight)
-	// slot, err := c.GetSlot(context.Background(), client.GetSlotConfig{client.CommitmentFinalized})
+	// slot, err := c.GetSlot(context.Background(), client.GetSlotConfig{Commitment: client.CommitmentFinalized})
 	// if err != nil {
 	// 	t.Fatal(err)
 	// }
@@ -0,0 +1,120 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func TestClusterQueries(t *testing.T) {
+	c, fake := newFakeClient(t, map[string]string{
+		"getVoteAccounts":      `{"current":[{"votePubkey":"vote","nodePubkey":"node","activatedStake":42,"epochVoteAccount":true,"commission":5,"lastVote":147,"rootSlot":100,"epochCredits":[[1,64,0],[2,192,64]]}],"delinquent":[]}`,
+		"getInflationReward":   `[{"epoch":2,"effectiveSlot":224,"amount":2500,"postBalance":499999442500,"commission":null},null]`,
+		"getInflationRate":     `{"epoch":100,"foundation":0.001,"total":0.149,"validator":0.148}`,
+		"getInflationGovernor": `{"foundation":0.05,"foundationTerm":7,"initial":0.15,"taper":0.15,"terminal":0.015}`,
+		"getEpochSchedule":     `{"firstNormalEpoch":8,"firstNormalSlot":8160,"leaderScheduleSlotOffset":8192,"slotsPerEpoch":8192,"warmup":true}`,
+		"getLeaderSchedule":    `{"node":[0,1,2,3]}`,
+		"getClusterNodes":      `[{"gossip":"10.239.6.48:8001","pubkey":"node","rpc":null,"tpu":"10.239.6.48:8856","version":"1.18.0","featureSet":1,"shredVersion":5}]`,
+	})
+	ctx := context.Background()
+
+	voteAccounts, err := c.GetVoteAccounts(ctx, client.GetVoteAccountsConfig{Commitment: client.CommitmentFinalized})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(voteAccounts.Current) != 1 || voteAccounts.Current[0].Commission != 5 || voteAccounts.Current[0].ActivatedStake != 42 {
+		t.Fatalf("vote accounts = %+v", voteAccounts)
+	}
+	want := []client.EpochCredits{{Epoch: 1, Credits: 64, PreviousCredits: 0}, {Epoch: 2, Credits: 192, PreviousCredits: 64}}
+	for i, credits := range voteAccounts.Current[0].EpochCredits {
+		if credits != want[i] {
+			t.Fatalf("epoch credits #%d = %+v, want %+v", i, credits, want[i])
+		}
+	}
+
+	epoch := uint64(2)
+	rewards, err := c.GetInflationReward(ctx, []string{"a", "b"}, client.GetInflationRewardConfig{Epoch: &epoch})
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := fake.lastParams()
+	if string(params[1]) != `{"epoch":2}` {
+		t.Fatalf("config = %s", params[1])
+	}
+	if len(rewards) != 2 || rewards[0].Amount != 2500 || rewards[0].Commission != nil || rewards[1] != nil {
+		t.Fatalf("rewards = %+v", rewards)
+	}
+
+	rate, err := c.GetInflationRate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate.Epoch != 100 || rate.Validator != 0.148 {
+		t.Fatalf("rate = %+v", rate)
+	}
+	governor, err := c.GetInflationGovernor(ctx, client.GetInflationGovernorConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if governor.Terminal != 0.015 {
+		t.Fatalf("governor = %+v", governor)
+	}
+
+	schedule, err := c.GetEpochSchedule(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !schedule.Warmup || schedule.FirstNormalSlot != 8160 {
+		t.Fatalf("schedule = %+v", schedule)
+	}
+
+	leaderSchedule, err := c.GetLeaderSchedule(ctx, nil, client.GetLeaderScheduleConfig{Identity: "node"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	params = fake.lastParams()
+	if string(params[0]) != "null" || string(params[1]) != `{"identity":"node"}` {
+		t.Fatalf("params = %s %s", params[0], params[1])
+	}
+	if len(leaderSchedule["node"]) != 4 {
+		t.Fatalf("leader schedule = %v", leaderSchedule)
+	}
+
+	nodes, err := c.GetClusterNodes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].Rpc != nil || nodes[0].Version == nil || *nodes[0].Version != "1.18.0" {
+		t.Fatalf("nodes = %+v", nodes)
+	}
+}
+
+func TestEpochSchedule(t *testing.T) {
+	warmup := client.EpochSchedule{SlotsPerEpoch: 8192, Warmup: true, FirstNormalEpoch: 8, FirstNormalSlot: 8160}
+	mainnet := client.EpochSchedule{SlotsPerEpoch: 432000}
+	tests := []struct {
+		schedule  client.EpochSchedule
+		slot      uint64
+		epoch     uint64
+		slotIndex uint64
+	}{
+		{schedule: warmup, slot: 0, epoch: 0, slotIndex: 0},
+		{schedule: warmup, slot: 31, epoch: 0, slotIndex: 31},
+		{schedule: warmup, slot: 32, epoch: 1, slotIndex: 0},
+		{schedule: warmup, slot: 96, epoch: 2, slotIndex: 0},
+		{schedule: warmup, slot: 8159, epoch: 7, slotIndex: 4095},
+		{schedule: warmup, slot: 8160, epoch: 8, slotIndex: 0},
+		{schedule: warmup, slot: 8160 + 8192*3 + 5, epoch: 11, slotIndex: 5},
+		{schedule: mainnet, slot: 432000*5 + 7, epoch: 5, slotIndex: 7},
+	}
+	for _, tt := range tests {
+		epoch, slotIndex := tt.schedule.EpochOfSlot(tt.slot)
+		if epoch != tt.epoch || slotIndex != tt.slotIndex {
+			t.Fatalf("slot %d: epoch %d index %d, want %d %d", tt.slot, epoch, slotIndex, tt.epoch, tt.slotIndex)
+		}
+		if first := tt.schedule.FirstSlotInEpoch(epoch); first != tt.slot-tt.slotIndex {
+			t.Fatalf("first slot of epoch %d = %d, want %d", epoch, first, tt.slot-tt.slotIndex)
+		}
+	}
+}
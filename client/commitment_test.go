@@ -0,0 +1,114 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+// lastConfig decodes the config object sent as the last param of the last request
+func lastConfig(fake *fakeRPC) map[string]interface{} {
+	params := fake.lastParams()
+	if len(params) == 0 {
+		return nil
+	}
+	cfg := map[string]interface{}{}
+	_ = json.Unmarshal(params[len(params)-1], &cfg)
+	return cfg
+}
+
+func TestGetLatestBlockhashSendsConfig(t *testing.T) {
+	c, fake := newFakeClient(t, map[string]string{
+		"getLatestBlockhash": `{"context":{"slot":77},"value":{"blockhash":"abc","lastValidBlockHeight":100}}`,
+	})
+
+	res, err := c.GetLatestBlockhashAndContext(context.Background(), client.GetLatestBlockhashConfig{
+		Commitment:     client.CommitmentFinalized,
+		MinContextSlot: 70,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg := lastConfig(fake); cfg["commitment"] != "finalized" || cfg["minContextSlot"] != float64(70) {
+		t.Fatalf("config not sent, got %v", cfg)
+	}
+	if res.Context.Slot != 77 || res.Value.Blockhash != "abc" || res.Value.LatestValidBlockHeight != 100 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestReadMethodsSendCommitment(t *testing.T) {
+	var fake *fakeRPC
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		result string
+		call   func(c *client.Client) error
+	}{
+		{"getAccountInfo", `{"context":{"slot":1},"value":null}`, func(c *client.Client) error {
+			res, err := c.GetAccountInfoAndContext(ctx, "11111111111111111111111111111111", client.GetAccountInfoConfig{
+				Commitment: client.CommitmentConfirmed, MinContextSlot: 5, Encoding: client.GetAccountInfoConfigEncodingBase64,
+			})
+			if err == nil && res.Value != nil {
+				t.Errorf("value = %+v, want nil", res.Value)
+			}
+			return err
+		}},
+		{"getBalance", `{"context":{"slot":1},"value":1}`, func(c *client.Client) error {
+			_, err := c.GetBalanceWithConfig(ctx, "11111111111111111111111111111111", client.GetBalanceConfig{
+				Commitment: client.CommitmentConfirmed, MinContextSlot: 5,
+			})
+			return err
+		}},
+		{"getSlot", `1`, func(c *client.Client) error {
+			_, err := c.GetSlot(ctx, client.GetSlotConfig{Commitment: client.CommitmentConfirmed, MinContextSlot: 5})
+			return err
+		}},
+		{"getBlockHeight", `1`, func(c *client.Client) error {
+			_, err := c.GetBlockHeight(ctx, client.GetBlockHeightConfig{Commitment: client.CommitmentConfirmed, MinContextSlot: 5})
+			return err
+		}},
+		{"getEpochInfo", `{"epoch":1}`, func(c *client.Client) error {
+			_, err := c.GetEpochInfoWithConfig(ctx, client.GetEpochInfoConfig{Commitment: client.CommitmentConfirmed, MinContextSlot: 5})
+			return err
+		}},
+		{"getProgramAccounts", `{"context":{"slot":1},"value":[]}`, func(c *client.Client) error {
+			commitment := client.CommitmentConfirmed
+			res, err := c.GetProgramAccountsAndContext(ctx, "11111111111111111111111111111111", client.GetProgramAccountsConfig{
+				Commitment: &commitment, MinContextSlot: 5, Encoding: client.GetAccountInfoConfigEncodingBase64,
+			})
+			if err == nil && (lastConfig(fake)["withContext"] != true || res.Context.Slot != 1) {
+				t.Errorf("withContext not set or context missing, cfg %v", lastConfig(fake))
+			}
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c *client.Client
+			c, fake = newFakeClient(t, map[string]string{tt.name: tt.result})
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+			if cfg := lastConfig(fake); cfg["commitment"] != "confirmed" || cfg["minContextSlot"] != float64(5) {
+				t.Fatalf("config not sent, got %v", cfg)
+			}
+		})
+	}
+}
+
+func TestGetProgramAccountsIgnoresWithContext(t *testing.T) {
+	c, fake := newFakeClient(t, map[string]string{"getProgramAccounts": `[]`})
+	_, err := c.GetProgramAccounts(context.Background(), "11111111111111111111111111111111", client.GetProgramAccountsConfig{
+		Encoding:    client.GetAccountInfoConfigEncodingBase64,
+		WithContext: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg := lastConfig(fake); cfg["withContext"] != nil {
+		t.Fatalf("withContext sent, cfg %v", cfg)
+	}
+}
@@ -1,13 +1,11 @@
 package client
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"sync"
 	"time"
 )
 
@@ -30,143 +28,161 @@ const (
 )
 
 type Client struct {
-	index        int
-	indexMutex   sync.Mutex
-	endpointList []string
+	pool        *endpointPool
+	transport   RPCTransport
+	retryPolicy RetryPolicy
 }
 
-func NewClient(endpointList []string) *Client {
+// Option configures a Client created by NewClient
+type Option func(*Client)
+
+// WithTransport replaces the default http transport, e.g. with an in-process fake in tests
+func WithTransport(transport RPCTransport) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
+// WithHTTPClient sends requests through httpClient, use it to set a custom
+// RoundTripper, proxy or tls config
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.transport = NewHTTPTransport(httpClient)
+	}
+}
+
+func NewClient(endpointList []string, opts ...Option) *Client {
 	if len(endpointList) == 0 {
 		panic("endpoint empty")
 	}
-	return &Client{endpointList: endpointList}
+	c := &Client{
+		pool:        newEndpointPool(endpointList),
+		transport:   NewHTTPTransport(nil),
+		retryPolicy: DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// Endpoint returns the healthiest endpoint, see EndpointStats
 func (s *Client) Endpoint() string {
-	return s.endpointList[s.index]
+	return s.pool.pick()
 }
 
+// ChangeEndpoint moves to the next endpoint in list order
 func (s *Client) ChangeEndpoint() {
-	s.indexMutex.Lock()
-	defer s.indexMutex.Unlock()
-
-	next := (s.index + 1) % len(s.endpointList)
-	s.index = next
+	s.pool.next()
 }
 
-// err will retry: 1) connection err 2) body read err 3) status code err
-// err will return: 1) reach retry err 2) rpc res error
-func (s *Client) request(ctx context.Context, method string, params []interface{}, response interface{}) error {
-	// post data
-	j, err := json.Marshal(map[string]interface{}{
+func encodeRequest(method string, params []interface{}) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      0,
 		"method":  method,
 		"params":  params,
 	})
+}
+
+// request sends method and decodes the result into response, see send for the retry rules
+func (s *Client) request(ctx context.Context, method string, params []interface{}, response interface{}) error {
+	j, err := encodeRequest(method, params)
 	if err != nil {
 		return err
 	}
 
-	retry := 0
-	for {
-		if retry > retryLimit {
-			return fmt.Errorf("httpclient reach retry limit, err: %s", err)
+	return s.send(ctx, j, func(body []byte) error {
+		return decodeResponse(body, response)
+	})
+}
+
+// send posts payload to the healthiest endpoint and passes the body to handle.
+// Errors accepted by the retry policy count against the endpoint and are retried on the
+// next endpoint after a backoff, others (e.g. deterministic rpc errors) are returned at once.
+func (s *Client) send(ctx context.Context, payload []byte, handle func(body []byte) error) error {
+	policy := s.retryPolicy
+	start := time.Now()
+	for retry := 0; ; retry++ {
+		endpoint := s.Endpoint()
+		begin := time.Now()
+		body, err := s.transport.Send(ctx, endpoint, payload)
+		if err == nil {
+			err = handle(body)
 		}
-		// post request
-		var req *http.Request
-		req, err = http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint(), bytes.NewBuffer(j))
-		if err != nil {
+		if err == nil || !policy.retryable(err) {
+			if ctx.Err() == nil {
+				s.pool.report(endpoint, time.Since(begin), endpointErr(err))
+			}
 			return err
 		}
-		req.Header.Add("Content-Type", "application/json")
-		// http client and send request
-		httpclient := &http.Client{}
-
-		var res *http.Response
-		res, err = httpclient.Do(req)
-		if err != nil {
-			time.Sleep(waitTime)
-			retry++
-			s.ChangeEndpoint()
-			continue
-		}
-		// check status code
-		if res.StatusCode != http.StatusOK {
-			err = fmt.Errorf("status code: %d, will retry", res.StatusCode)
-			// fmt.Println(err, " post: ", string(j))
-			time.Sleep(waitTime)
-			retry++
-			res.Body.Close()
-			s.ChangeEndpoint()
-			continue
-		}
+		s.pool.report(endpoint, time.Since(begin), err)
+		s.pool.rotate(endpoint)
 
-		// parse body
-		var body []byte
-		body, err = io.ReadAll(res.Body)
-		if err != nil {
-			time.Sleep(waitTime)
-			retry++
-			res.Body.Close()
-			s.ChangeEndpoint()
-			continue
+		if retry >= policy.MaxRetries {
+			return fmt.Errorf("httpclient reach retry limit, err: %w", err)
 		}
-
-		if len(body) == 0 {
-			err = fmt.Errorf("body empty")
-			time.Sleep(waitTime)
-			retry++
-			res.Body.Close()
-			s.ChangeEndpoint()
-			continue
+		wait := policy.backoff(retry)
+		if policy.MaxElapsedTime > 0 && time.Since(start)+wait > policy.MaxElapsedTime {
+			return fmt.Errorf("httpclient reach max elapsed time, err: %w", err)
 		}
-
-		generayRes := GeneralResponse{}
-		err = json.Unmarshal(body, &generayRes)
-		if err != nil {
-			time.Sleep(waitTime)
-			retry++
-			res.Body.Close()
-			s.ChangeEndpoint()
-			continue
+		if ctxErr := sleepCtx(ctx, wait); ctxErr != nil {
+			return fmt.Errorf("%w, last err: %s", ctxErr, err)
 		}
+	}
+}
 
-		if generayRes.Error != (ErrorResponse{}) {
-			err = fmt.Errorf("find generalResponse err, will retry. code: %d, msg: %s, use rpc: %s", generayRes.Error.Code, generayRes.Error.Message, s.Endpoint())
-			// fmt.Println(err, " post: ", string(j))
-			time.Sleep(waitTime)
-			retry++
-			res.Body.Close()
-			s.ChangeEndpoint()
-			continue
-		}
+// endpointErr drops err when the node answered it, a deterministic rpc error says nothing
+// about the endpoint's health while a bad body or a refused connection does
+func endpointErr(err error) error {
+	var rpcErr *RPCError
+	if err == nil || errors.As(err, &rpcErr) {
+		return nil
+	}
+	return err
+}
 
-		if err = json.Unmarshal(body, &response); err != nil {
-			time.Sleep(waitTime)
-			retry++
-			res.Body.Close()
-			s.ChangeEndpoint()
-			continue
-		}
+// call does a single round trip to endpoint
+func (s *Client) call(ctx context.Context, endpoint string, payload []byte, response interface{}) error {
+	body, err := s.transport.Send(ctx, endpoint, payload)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(body, response)
+}
 
-		res.Body.Close()
-		break
+func decodeResponse(body []byte, response interface{}) error {
+	generayRes := GeneralResponse{}
+	err := json.Unmarshal(body, &generayRes)
+	if err != nil {
+		return err
 	}
-	return nil
+	if generayRes.Error != nil {
+		return newRPCError(*generayRes.Error)
+	}
+
+	return json.Unmarshal(body, &response)
 }
 
 type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
 }
 
 type Context struct {
 	Slot uint64 `json:"slot"`
 }
 
+// ValueWithContext is the result of the rpc methods which return the context
+// slot the value was read at alongside the value
+type ValueWithContext[T any] struct {
+	Context Context `json:"context"`
+	Value   T       `json:"value"`
+}
+
 type GeneralResponse struct {
-	JsonRPC string        `json:"jsonrpc"`
-	ID      uint64        `json:"id"`
-	Error   ErrorResponse `json:"error"`
+	JsonRPC string         `json:"jsonrpc"`
+	ID      uint64         `json:"id"`
+	Error   *ErrorResponse `json:"error"`
 }
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// json rpc error codes returned by solana nodes
+const (
+	RPCCodeBlockCleanedUp                     = -32001
+	RPCCodeSendTransactionPreflightFailure    = -32002
+	RPCCodeTransactionSignatureVerifyFailure  = -32003
+	RPCCodeBlockNotAvailable                  = -32004
+	RPCCodeNodeUnhealthy                      = -32005
+	RPCCodeTransactionPrecompileVerifyFailure = -32006
+	RPCCodeSlotSkipped                        = -32007
+	RPCCodeNoSnapshot                         = -32008
+	RPCCodeLongTermStorageSlotSkipped         = -32009
+	RPCCodeKeyExcludedFromSecondaryIndex      = -32010
+	RPCCodeTransactionHistoryNotAvailable     = -32011
+	RPCCodeScanError                          = -32012
+	RPCCodeTransactionSignatureLenMismatch    = -32013
+	RPCCodeBlockStatusNotAvailableYet         = -32014
+	RPCCodeUnsupportedTransactionVersion      = -32015
+	RPCCodeMinContextSlotNotReached           = -32016
+
+	RPCCodeParseError     = -32700
+	RPCCodeInvalidRequest = -32600
+	RPCCodeMethodNotFound = -32601
+	RPCCodeInvalidParams  = -32602
+	RPCCodeInternalError  = -32603
+)
+
+// sentinel errors, match them with errors.Is against an error returned by Client
+var (
+	ErrBlockhashNotFound           = errors.New("blockhash not found")
+	ErrNodeBehind                  = errors.New("node is behind")
+	ErrSlotSkipped                 = errors.New("slot skipped")
+	ErrMinContextSlotNotReached    = errors.New("minimum context slot has not been reached")
+	ErrTransactionSimulationFailed = errors.New("transaction simulation failed")
+)
+
+// RPCError is the error object returned by the json rpc server
+type RPCError struct {
+	Code    int
+	Message string
+	Data    json.RawMessage
+	// Preflight is the decoded Data of a sendTransaction preflight failure, nil otherwise
+	Preflight *PreflightFailure
+}
+
+// PreflightFailure is the simulation result attached to a failed sendTransaction preflight check
+type PreflightFailure struct {
+	Err           *TransactionError `json:"err"`
+	Logs          []string          `json:"logs"`
+	UnitsConsumed *uint64           `json:"unitsConsumed"`
+}
+
+func newRPCError(res ErrorResponse) *RPCError {
+	rpcErr := &RPCError{
+		Code:    res.Code,
+		Message: res.Message,
+		Data:    res.Data,
+	}
+	if res.Code == RPCCodeSendTransactionPreflightFailure && len(res.Data) != 0 {
+		preflight := PreflightFailure{}
+		if err := json.Unmarshal(res.Data, &preflight); err == nil {
+			rpcErr.Preflight = &preflight
+		}
+	}
+	return rpcErr
+}
+
+func (e *RPCError) Error() string {
+	msg := fmt.Sprintf("rpc error, code: %d, msg: %s", e.Code, e.Message)
+	if e.Preflight != nil && e.Preflight.Err != nil {
+		msg = fmt.Sprintf("%s, err: %s", msg, e.Preflight.Err)
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *RPCError) Is(target error) bool {
+	switch target {
+	case ErrBlockhashNotFound:
+		if e.Preflight != nil && e.Preflight.Err != nil && e.Preflight.Err.Kind == TransactionErrorBlockhashNotFound {
+			return true
+		}
+		return strings.Contains(strings.ToLower(e.Message), "blockhash not found")
+	case ErrNodeBehind:
+		return e.Code == RPCCodeNodeUnhealthy
+	case ErrSlotSkipped:
+		return e.Code == RPCCodeSlotSkipped || e.Code == RPCCodeLongTermStorageSlotSkipped
+	case ErrMinContextSlotNotReached:
+		return e.Code == RPCCodeMinContextSlotNotReached
+	case ErrTransactionSimulationFailed:
+		return e.Code == RPCCodeSendTransactionPreflightFailure
+	}
+	return false
+}
+
+// Logs returns the program logs of a failed preflight simulation
+func (e *RPCError) Logs() []string {
+	if e.Preflight == nil {
+		return nil
+	}
+	return e.Preflight.Logs
+}
+
+const (
+	TransactionErrorBlockhashNotFound = "BlockhashNotFound"
+	TransactionErrorInstructionError  = "InstructionError"
+)
+
+// TransactionError is the decoded `err` of a transaction, e.g. "BlockhashNotFound"
+// or {"InstructionError":[0,{"Custom":1}]}
+type TransactionError struct {
+	Kind             string
+	InstructionError *InstructionError // set if Kind is InstructionError
+	Detail           json.RawMessage   // payload of other non unit kinds
+}
+
+// InstructionError is the error of the instruction at Index
+type InstructionError struct {
+	Index  uint8
+	Kind   string  // e.g. "Custom", "InvalidAccountData"
+	Custom *uint32 // program error code if Kind is Custom
+	Detail json.RawMessage
+}
+
+func (e *TransactionError) UnmarshalJSON(data []byte) error {
+	var kind string
+	if err := json.Unmarshal(data, &kind); err == nil {
+		*e = TransactionError{Kind: kind}
+		return nil
+	}
+
+	obj := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("transaction error unmarshal err: %w", err)
+	}
+	if len(obj) != 1 {
+		return fmt.Errorf("transaction error has %d kinds", len(obj))
+	}
+	for k, v := range obj {
+		*e = TransactionError{Kind: k, Detail: v}
+	}
+	if e.Kind != TransactionErrorInstructionError {
+		return nil
+	}
+
+	tuple := []json.RawMessage{}
+	if err := json.Unmarshal(e.Detail, &tuple); err != nil || len(tuple) != 2 {
+		return fmt.Errorf("instruction error format err: %s", string(e.Detail))
+	}
+	insErr := InstructionError{}
+	if err := json.Unmarshal(tuple[0], &insErr.Index); err != nil {
+		return fmt.Errorf("instruction error index err: %w", err)
+	}
+	if err := json.Unmarshal(tuple[1], &insErr.Kind); err != nil {
+		insObj := map[string]json.RawMessage{}
+		if err := json.Unmarshal(tuple[1], &insObj); err != nil || len(insObj) != 1 {
+			return fmt.Errorf("instruction error kind err: %s", string(tuple[1]))
+		}
+		for k, v := range insObj {
+			insErr.Kind = k
+			insErr.Detail = v
+		}
+		if insErr.Kind == "Custom" {
+			code := uint32(0)
+			if err := json.Unmarshal(insErr.Detail, &code); err != nil {
+				return fmt.Errorf("instruction custom err: %w", err)
+			}
+			insErr.Custom = &code
+		}
+	}
+	e.InstructionError = &insErr
+	return nil
+}
+
+func (e TransactionError) MarshalJSON() ([]byte, error) {
+	if e.InstructionError != nil {
+		var kind interface{} = e.InstructionError.Kind
+		if e.InstructionError.Custom != nil {
+			kind = map[string]uint32{"Custom": *e.InstructionError.Custom}
+		} else if len(e.InstructionError.Detail) != 0 {
+			kind = map[string]json.RawMessage{e.InstructionError.Kind: e.InstructionError.Detail}
+		}
+		return json.Marshal(map[string]interface{}{
+			e.Kind: []interface{}{e.InstructionError.Index, kind},
+		})
+	}
+	if len(e.Detail) != 0 {
+		return json.Marshal(map[string]json.RawMessage{e.Kind: e.Detail})
+	}
+	return json.Marshal(e.Kind)
+}
+
+func (e *TransactionError) Error() string {
+	if e.InstructionError != nil {
+		return fmt.Sprintf("%s: instruction #%d %s", e.Kind, e.InstructionError.Index, e.InstructionError)
+	}
+	if len(e.Detail) != 0 {
+		return fmt.Sprintf("%s: %s", e.Kind, string(bytes.TrimSpace(e.Detail)))
+	}
+	return e.Kind
+}
+
+func (e *InstructionError) String() string {
+	if e.Custom != nil {
+		return fmt.Sprintf("Custom(%d)", *e.Custom)
+	}
+	if len(e.Detail) != 0 {
+		return fmt.Sprintf("%s(%s)", e.Kind, string(e.Detail))
+	}
+	return e.Kind
+}
@@ -0,0 +1,95 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func TestRPCErrorPreflightFailure(t *testing.T) {
+	c, fake := newFakeClient(t, nil)
+	fake.fail("sendTransaction", `{"code":-32002,"message":"Transaction simulation failed: Error processing Instruction 1: custom program error: 0x1771","data":{"accounts":null,"err":{"InstructionError":[1,{"Custom":6001}]},"logs":["Program log: Error: EraNotMatch"],"unitsConsumed":1500}}`)
+
+	_, err := c.SendTransaction(context.Background(), "", client.SendTransactionConfig{})
+	if !errors.Is(err, client.ErrTransactionSimulationFailed) {
+		t.Fatalf("err = %v, want simulation failed", err)
+	}
+	if errors.Is(err, client.ErrBlockhashNotFound) {
+		t.Fatalf("err = %v, should not be blockhash not found", err)
+	}
+	var rpcErr *client.RPCError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("err = %v, want *RPCError", err)
+	}
+	if len(rpcErr.Logs()) != 1 || rpcErr.Logs()[0] != "Program log: Error: EraNotMatch" {
+		t.Fatalf("logs = %v", rpcErr.Logs())
+	}
+	insErr := rpcErr.Preflight.Err.InstructionError
+	if insErr == nil || insErr.Index != 1 || insErr.Custom == nil || *insErr.Custom != 6001 {
+		t.Fatalf("instruction error = %+v", insErr)
+	}
+}
+
+func TestRPCErrorSentinel(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    string
+		target error
+	}{
+		{
+			name:   "blockhash not found",
+			err:    `{"code":-32002,"message":"Transaction simulation failed: Blockhash not found","data":{"err":"BlockhashNotFound","logs":[]}}`,
+			target: client.ErrBlockhashNotFound,
+		},
+		{
+			name:   "node behind",
+			err:    `{"code":-32005,"message":"Node is behind by 1024 slots","data":{"numSlotsBehind":1024}}`,
+			target: client.ErrNodeBehind,
+		},
+		{
+			name:   "slot skipped",
+			err:    `{"code":-32007,"message":"Slot 1 was skipped, or missing due to ledger jump to recent snapshot"}`,
+			target: client.ErrSlotSkipped,
+		},
+		{
+			name:   "min context slot",
+			err:    `{"code":-32016,"message":"Minimum context slot has not been reached","data":{"contextSlot":10}}`,
+			target: client.ErrMinContextSlotNotReached,
+		},
+	}
+	policy := client.RetryPolicy{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, fake := newFakeClient(t, nil, client.WithRetryPolicy(policy))
+			fake.fail("getSlot", tt.err)
+			_, err := c.GetSlot(context.Background(), client.GetSlotConfig{})
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("err = %v, want %v", err, tt.target)
+			}
+		})
+	}
+}
+
+func TestTransactionErrorJSON(t *testing.T) {
+	for _, raw := range []string{
+		`"AccountNotFound"`,
+		`{"InstructionError":[0,{"Custom":1}]}`,
+		`{"InstructionError":[2,"InvalidAccountData"]}`,
+		`{"InsufficientFundsForRent":{"account_index":2}}`,
+	} {
+		txErr := client.TransactionError{}
+		if err := json.Unmarshal([]byte(raw), &txErr); err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(txErr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != raw {
+			t.Fatalf("marshal = %s, want %s", got, raw)
+		}
+	}
+}
@@ -14,16 +14,23 @@ const (
 	GetAccountInfoConfigEncodingBase58     GetAccountInfoConfigEncoding = "base58" // limited to Account data of less than 128 bytes
 	GetAccountInfoConfigEncodingBase64     GetAccountInfoConfigEncoding = "base64"
 	GetAccountInfoConfigEncodingBase64Zstd GetAccountInfoConfigEncoding = "base64+zstd"
+	// returns the data of accounts owned by programs the node knows (token, stake, nonce, ...)
+	// as JSON, other accounts fall back to base64
+	GetAccountInfoConfigEncodingJsonParsed GetAccountInfoConfigEncoding = "jsonParsed"
 )
 
 type GetAccountInfoConfig struct {
-	Encoding  GetAccountInfoConfigEncoding
-	DataSlice GetAccountInfoConfigDataSlice
+	Commitment     Commitment
+	MinContextSlot uint64
+	Encoding       GetAccountInfoConfigEncoding
+	DataSlice      GetAccountInfoConfigDataSlice
 }
 
 type getAccountInfo struct {
-	Encoding  GetAccountInfoConfigEncoding   `json:"encoding"`
-	DataSlice *GetAccountInfoConfigDataSlice `json:"dataSlice,omitempty"`
+	Commitment     Commitment                     `json:"commitment,omitempty"`
+	MinContextSlot uint64                         `json:"minContextSlot,omitempty"`
+	Encoding       GetAccountInfoConfigEncoding   `json:"encoding"`
+	DataSlice      *GetAccountInfoConfigDataSlice `json:"dataSlice,omitempty"`
 }
 
 func (cfg GetAccountInfoConfig) MarshalJSON() ([]byte, error) {
@@ -32,8 +39,10 @@ func (cfg GetAccountInfoConfig) MarshalJSON() ([]byte, error) {
 		dataSlice = &cfg.DataSlice
 	}
 	return json.Marshal(getAccountInfo{
-		Encoding:  cfg.Encoding,
-		DataSlice: dataSlice,
+		Commitment:     cfg.Commitment,
+		MinContextSlot: cfg.MinContextSlot,
+		Encoding:       cfg.Encoding,
+		DataSlice:      dataSlice,
 	})
 }
 
@@ -43,32 +52,61 @@ type GetAccountInfoConfigDataSlice struct {
 }
 
 type GetAccountInfoResponse struct {
-	Lamports  uint64      `json:"lamports"`
-	Owner     string      `json:"owner"`
-	Excutable bool        `json:"excutable"`
-	RentEpoch uint64      `json:"rentEpoch"`
-	Data      interface{} `json:"data"`
+	Lamports   uint64      `json:"lamports"`
+	Owner      string      `json:"owner"`
+	Executable bool        `json:"executable"`
+	RentEpoch  uint64      `json:"rentEpoch"`
+	Space      uint64      `json:"space"` // length of the whole data, Data may be a slice of it
+	Data       AccountData `json:"data"`
+
+	// Deprecated: use Executable, it is set to the same value
+	Excutable bool `json:"-"`
+}
+
+func (r *GetAccountInfoResponse) UnmarshalJSON(b []byte) error {
+	type alias GetAccountInfoResponse
+	if err := json.Unmarshal(b, (*alias)(r)); err != nil {
+		return err
+	}
+	r.Excutable = r.Executable
+	return nil
 }
 
 func (s *Client) GetAccountInfo(ctx context.Context, account string, cfg GetAccountInfoConfig) (GetAccountInfoResponse, error) {
+	_, value, err := s.getAccountInfo(ctx, account, cfg)
+	if err != nil {
+		return GetAccountInfoResponse{}, err
+	}
+	// not found err
+	if value == nil {
+		return GetAccountInfoResponse{}, ErrAccountNotFound
+	}
+	return *value, nil
+}
+
+// GetAccountInfoAndContext returns the account info with the slot it was read at,
+// the value is nil if the account is not found. Unlike GetAccountInfo a missing
+// account is not an error, which tells it apart from a failed request
+func (s *Client) GetAccountInfoAndContext(ctx context.Context, account string, cfg GetAccountInfoConfig) (ValueWithContext[*GetAccountInfoResponse], error) {
+	rpcCtx, value, err := s.getAccountInfo(ctx, account, cfg)
+	if err != nil {
+		return ValueWithContext[*GetAccountInfoResponse]{}, err
+	}
+	return ValueWithContext[*GetAccountInfoResponse]{Context: rpcCtx, Value: value}, nil
+}
+
+// getAccountInfo returns a nil value if the account is not found
+func (s *Client) getAccountInfo(ctx context.Context, account string, cfg GetAccountInfoConfig) (Context, *GetAccountInfoResponse, error) {
 	res := struct {
 		GeneralResponse
 		Result struct {
-			Context Context                `json:"context"`
-			Value   GetAccountInfoResponse `json:"value"`
+			Context Context                 `json:"context"`
+			Value   *GetAccountInfoResponse `json:"value"`
 		} `json:"result"`
 	}{}
 	err := s.request(ctx, "getAccountInfo", []interface{}{account, cfg}, &res)
 	if err != nil {
-		return GetAccountInfoResponse{}, err
-	}
-	//rpc err
-	if res.Error != (ErrorResponse{}) {
-		return GetAccountInfoResponse{}, errors.New(res.Error.Message)
-	}
-	// not found err
-	if res.Result.Value == (GetAccountInfoResponse{}) {
-		return GetAccountInfoResponse{}, ErrAccountNotFound
+		return Context{}, nil, err
 	}
-	return res.Result.Value, nil
+	return res.Result.Context, res.Result.Value, nil
 }
@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func TestGetAccountInfoNotFound(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantFound bool
+	}{
+		{name: "null", value: `null`},
+		{
+			name:      "zero lamports",
+			value:     `{"lamports":0,"owner":"","executable":false,"rentEpoch":0,"space":0,"data":["","base64"]}`,
+			wantFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newFakeClient(t, map[string]string{"getAccountInfo": `{"context":{"slot":9},"value":` + tt.value + `}`})
+			cfg := client.GetAccountInfoConfig{Encoding: client.GetAccountInfoConfigEncodingBase64}
+
+			_, err := c.GetAccountInfo(context.Background(), "11111111111111111111111111111111", cfg)
+			if tt.wantFound && err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantFound && !errors.Is(err, client.ErrAccountNotFound) {
+				t.Fatalf("err = %v, want ErrAccountNotFound", err)
+			}
+
+			res, err := c.GetAccountInfoAndContext(context.Background(), "11111111111111111111111111111111", cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Context.Slot != 9 {
+				t.Fatalf("slot = %d, want 9", res.Context.Slot)
+			}
+			if (res.Value != nil) != tt.wantFound {
+				t.Fatalf("found = %v, want %v", res.Value != nil, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestGetAccountInfoFields(t *testing.T) {
+	c, _ := newFakeClient(t, map[string]string{
+		"getAccountInfo": `{"context":{"slot":9},"value":{"lamports":1141440,"owner":"BPFLoaderUpgradeab1e11111111111111111111111","executable":true,"rentEpoch":18446744073709551615,"space":36,"data":["AgAAAA==","base64"]}}`,
+	})
+
+	accountInfo, err := c.GetAccountInfo(context.Background(), "11111111111111111111111111111111", client.GetAccountInfoConfig{
+		Encoding:  client.GetAccountInfoConfigEncodingBase64,
+		DataSlice: client.GetAccountInfoConfigDataSlice{Offset: 0, Length: 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !accountInfo.Executable || !accountInfo.Excutable {
+		t.Fatal("executable must be true")
+	}
+	if accountInfo.RentEpoch != 18446744073709551615 {
+		t.Fatalf("rent epoch = %d", accountInfo.RentEpoch)
+	}
+	if accountInfo.Space != 36 || len(accountInfo.Data.Bytes()) != 4 {
+		t.Fatalf("space = %d, data = %d bytes", accountInfo.Space, len(accountInfo.Data.Bytes()))
+	}
+}
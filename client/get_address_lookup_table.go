@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+
+	"github.com/stafiprotocol/solana-go-sdk/altprog"
+)
+
+// lookup tables grow as they are extended, so the whole account is fetched
+var GetAddressLookupTableCfgDefault = GetAccountInfoConfig{
+	Encoding: GetAccountInfoConfigEncodingBase64,
+}
+
+func (s *Client) GetAddressLookupTable(ctx context.Context, account string) (*altprog.LookupTable, error) {
+	accountInfo, err := s.GetAccountInfo(ctx, account, GetAddressLookupTableCfgDefault)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAddressLookupTable(accountInfo)
+}
+
+func decodeAddressLookupTable(accountInfo GetAccountInfoResponse) (*altprog.LookupTable, error) {
+	accountDataBts, err := accountDataBytes(accountInfo)
+	if err != nil {
+		return nil, err
+	}
+	lookupTable, err := altprog.LookupTableDeserialize(accountDataBts)
+	if err != nil {
+		return nil, err
+	}
+	return &lookupTable, nil
+}
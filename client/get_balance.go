@@ -1,24 +1,33 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
+
+type GetBalanceConfig struct {
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
+}
 
 func (s *Client) GetBalance(ctx context.Context, base58Addr string) (uint64, error) {
+	return s.GetBalanceWithConfig(ctx, base58Addr, GetBalanceConfig{})
+}
+
+func (s *Client) GetBalanceWithConfig(ctx context.Context, base58Addr string, cfg GetBalanceConfig) (uint64, error) {
+	res, err := s.GetBalanceAndContext(ctx, base58Addr, cfg)
+	if err != nil {
+		return 0, err
+	}
+	return res.Value, nil
+}
+
+// GetBalanceAndContext returns the balance with the slot it was read at
+func (s *Client) GetBalanceAndContext(ctx context.Context, base58Addr string, cfg GetBalanceConfig) (ValueWithContext[uint64], error) {
 	res := struct {
 		GeneralResponse
-		Result struct {
-			Context Context `json:"context"`
-			Value   uint64  `json:"value"`
-		} `json:"result"`
+		Result ValueWithContext[uint64] `json:"result"`
 	}{}
-	err := s.request(ctx, "getBalance", []interface{}{base58Addr}, &res)
+	err := s.request(ctx, "getBalance", []interface{}{base58Addr, cfg}, &res)
 	if err != nil {
-		return 0, err
-	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
+		return ValueWithContext[uint64]{}, err
 	}
-	return res.Result.Value, nil
+	return res.Result, nil
 }
@@ -16,8 +16,9 @@ type GetBlockResponse struct {
 	ParentSLot        uint64 `json:"parentSlot"`
 	BlockTime         int64  `json:"blockTime"`
 	Transactions      []struct {
-		Meta        TransactionMeta `json:"meta"`
-		Transaction Transaction     `json:"transaction"`
+		Meta        TransactionMeta    `json:"meta"`
+		Transaction Transaction        `json:"transaction"`
+		Version     TransactionVersion `json:"version"`
 	} `json:"transactions"`
 	Rewards []struct {
 		Pubkey      string `json:"pubkey"`
@@ -1,12 +1,10 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetBlockHeightConfig struct {
-	Commitment Commitment `json:"commitment,omitempty"`
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
 }
 
 // GetBlockHeight returns the current block height of the node
@@ -19,8 +17,5 @@ func (s *Client) GetBlockHeight(ctx context.Context, cfg GetBlockHeightConfig) (
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
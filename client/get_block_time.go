@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // GetSlob returns the current slot  of the node
 func (s *Client) GetBlockTime(ctx context.Context, slot uint64) (uint64, error) {
@@ -15,8 +12,5 @@ func (s *Client) GetBlockTime(ctx context.Context, slot uint64) (uint64, error)
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
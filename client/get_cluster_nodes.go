@@ -0,0 +1,28 @@
+package client
+
+import "context"
+
+// ClusterNode is a node seen in gossip, the optional fields are nil if the node
+// does not advertise them
+type ClusterNode struct {
+	Pubkey       string  `json:"pubkey"`
+	Gossip       *string `json:"gossip"`
+	Tpu          *string `json:"tpu"`
+	TpuQuic      *string `json:"tpuQuic"`
+	Rpc          *string `json:"rpc"`
+	Version      *string `json:"version"`
+	FeatureSet   *uint32 `json:"featureSet"`
+	ShredVersion *uint16 `json:"shredVersion"`
+}
+
+func (s *Client) GetClusterNodes(ctx context.Context) ([]ClusterNode, error) {
+	res := struct {
+		GeneralResponse
+		Result []ClusterNode `json:"result"`
+	}{}
+	err := s.request(ctx, "getClusterNodes", []interface{}{}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
@@ -10,12 +10,21 @@ type GetEpochInfoResponse struct {
 	SlotsInEpoch int `json:"slotsInEpoch"`
 }
 
+type GetEpochInfoConfig struct {
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
+}
+
 func (s *Client) GetEpochInfo(ctx context.Context, commitment Commitment) (GetEpochInfoResponse, error) {
+	return s.GetEpochInfoWithConfig(ctx, GetEpochInfoConfig{Commitment: commitment})
+}
+
+func (s *Client) GetEpochInfoWithConfig(ctx context.Context, cfg GetEpochInfoConfig) (GetEpochInfoResponse, error) {
 	res := struct {
 		GeneralResponse
 		Result GetEpochInfoResponse `json:"result"`
 	}{}
-	err := s.request(ctx, "getEpochInfo", []interface{}{map[string]interface{}{"commitment": commitment}}, &res)
+	err := s.request(ctx, "getEpochInfo", []interface{}{cfg}, &res)
 	if err != nil {
 		return GetEpochInfoResponse{}, err
 	}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"math/bits"
+)
+
+// minimumSlotsPerEpoch is the length of the first epoch when the schedule warms up
+const minimumSlotsPerEpoch = 32
+
+type EpochSchedule struct {
+	SlotsPerEpoch            uint64 `json:"slotsPerEpoch"`
+	LeaderScheduleSlotOffset uint64 `json:"leaderScheduleSlotOffset"`
+	Warmup                   bool   `json:"warmup"`
+	FirstNormalEpoch         uint64 `json:"firstNormalEpoch"`
+	FirstNormalSlot          uint64 `json:"firstNormalSlot"`
+}
+
+// EpochOfSlot returns the epoch of slot and the index of slot in it. While warming up
+// epochs start at 32 slots and double until they reach SlotsPerEpoch
+func (e EpochSchedule) EpochOfSlot(slot uint64) (epoch uint64, slotIndex uint64) {
+	if slot < e.FirstNormalSlot {
+		epoch = uint64(bits.Len64(slot+minimumSlotsPerEpoch)) - uint64(bits.Len64(minimumSlotsPerEpoch))
+		epochLen := uint64(minimumSlotsPerEpoch) << epoch
+		return epoch, slot - (epochLen - minimumSlotsPerEpoch)
+	}
+	normalSlotIndex := slot - e.FirstNormalSlot
+	return e.FirstNormalEpoch + normalSlotIndex/e.SlotsPerEpoch, normalSlotIndex % e.SlotsPerEpoch
+}
+
+// FirstSlotInEpoch is the inverse of EpochOfSlot
+func (e EpochSchedule) FirstSlotInEpoch(epoch uint64) uint64 {
+	if epoch <= e.FirstNormalEpoch {
+		return (uint64(1)<<epoch - 1) * minimumSlotsPerEpoch
+	}
+	return (epoch-e.FirstNormalEpoch)*e.SlotsPerEpoch + e.FirstNormalSlot
+}
+
+func (s *Client) GetEpochSchedule(ctx context.Context) (EpochSchedule, error) {
+	res := struct {
+		GeneralResponse
+		Result EpochSchedule `json:"result"`
+	}{}
+	err := s.request(ctx, "getEpochSchedule", []interface{}{}, &res)
+	if err != nil {
+		return EpochSchedule{}, err
+	}
+	return res.Result, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import "context"
+
+type InflationRate struct {
+	Total      float64 `json:"total"`
+	Validator  float64 `json:"validator"`
+	Foundation float64 `json:"foundation"`
+	Epoch      uint64  `json:"epoch"`
+}
+
+type GetInflationGovernorConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
+
+type InflationGovernor struct {
+	Initial        float64 `json:"initial"`
+	Terminal       float64 `json:"terminal"`
+	Taper          float64 `json:"taper"`
+	Foundation     float64 `json:"foundation"`
+	FoundationTerm float64 `json:"foundationTerm"`
+}
+
+// GetInflationRate returns the inflation values of the current epoch
+func (s *Client) GetInflationRate(ctx context.Context) (InflationRate, error) {
+	res := struct {
+		GeneralResponse
+		Result InflationRate `json:"result"`
+	}{}
+	err := s.request(ctx, "getInflationRate", []interface{}{}, &res)
+	if err != nil {
+		return InflationRate{}, err
+	}
+	return res.Result, nil
+}
+
+func (s *Client) GetInflationGovernor(ctx context.Context, cfg GetInflationGovernorConfig) (InflationGovernor, error) {
+	res := struct {
+		GeneralResponse
+		Result InflationGovernor `json:"result"`
+	}{}
+	err := s.request(ctx, "getInflationGovernor", []interface{}{cfg}, &res)
+	if err != nil {
+		return InflationGovernor{}, err
+	}
+	return res.Result, nil
+}
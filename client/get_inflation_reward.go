@@ -0,0 +1,31 @@
+package client
+
+import "context"
+
+type GetInflationRewardConfig struct {
+	Commitment     Commitment `json:"commitment,omitempty"`
+	Epoch          *uint64    `json:"epoch,omitempty"` // defaults to the last finished epoch
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
+}
+
+type InflationReward struct {
+	Epoch         uint64 `json:"epoch"`
+	EffectiveSlot uint64 `json:"effectiveSlot"`
+	Amount        uint64 `json:"amount"` // lamports
+	PostBalance   uint64 `json:"postBalance"`
+	Commission    *uint8 `json:"commission"` // set for vote accounts
+}
+
+// GetInflationReward returns the rewards of the addresses in the given epoch, in the order
+// of addresses. Addresses which were not rewarded are nil
+func (s *Client) GetInflationReward(ctx context.Context, addresses []string, cfg GetInflationRewardConfig) ([]*InflationReward, error) {
+	res := struct {
+		GeneralResponse
+		Result []*InflationReward `json:"result"`
+	}{}
+	err := s.request(ctx, "getInflationReward", []interface{}{addresses, cfg}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetLatestBlockHashResponse struct {
 	Blockhash              string `json:"blockhash"`
@@ -11,23 +8,27 @@ type GetLatestBlockHashResponse struct {
 }
 
 type GetLatestBlockhashConfig struct {
-	Commitment Commitment `json:"commitment,omitempty"`
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
 }
 
 func (s *Client) GetLatestBlockhash(ctx context.Context, cfg GetLatestBlockhashConfig) (GetLatestBlockHashResponse, error) {
+	res, err := s.GetLatestBlockhashAndContext(ctx, cfg)
+	if err != nil {
+		return GetLatestBlockHashResponse{}, err
+	}
+	return res.Value, nil
+}
+
+// GetLatestBlockhashAndContext returns the latest blockhash with the slot it was read at
+func (s *Client) GetLatestBlockhashAndContext(ctx context.Context, cfg GetLatestBlockhashConfig) (ValueWithContext[GetLatestBlockHashResponse], error) {
 	res := struct {
 		GeneralResponse
-		Result struct {
-			Context Context                    `json:"context"`
-			Value   GetLatestBlockHashResponse `json:"value"`
-		} `json:"result"`
+		Result ValueWithContext[GetLatestBlockHashResponse] `json:"result"`
 	}{}
-	err := s.request(ctx, "getLatestBlockhash", []interface{}{}, &res)
+	err := s.request(ctx, "getLatestBlockhash", []interface{}{cfg}, &res)
 	if err != nil {
-		return GetLatestBlockHashResponse{}, err
-	}
-	if res.Error != (ErrorResponse{}) {
-		return GetLatestBlockHashResponse{}, errors.New(res.Error.Message)
+		return ValueWithContext[GetLatestBlockHashResponse]{}, err
 	}
-	return res.Result.Value, nil
+	return res.Result, nil
 }
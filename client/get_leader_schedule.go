@@ -0,0 +1,23 @@
+package client
+
+import "context"
+
+type GetLeaderScheduleConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+	Identity   string     `json:"identity,omitempty"` // only return the slots of this validator
+}
+
+// GetLeaderSchedule returns the leader schedule of the epoch holding slot, or of the current
+// epoch if slot is nil. It maps validator identities to their slot indexes, relative to
+// the first slot of the epoch. The map is nil if the epoch has no schedule yet
+func (s *Client) GetLeaderSchedule(ctx context.Context, slot *uint64, cfg GetLeaderScheduleConfig) (map[string][]uint64, error) {
+	res := struct {
+		GeneralResponse
+		Result map[string][]uint64 `json:"result"`
+	}{}
+	err := s.request(ctx, "getLeaderSchedule", []interface{}{slot, cfg}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
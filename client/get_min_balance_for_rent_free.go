@@ -1,21 +1,23 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
+
+type GetMinimumBalanceForRentExemptionConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
 
 func (s *Client) GetMinimumBalanceForRentExemption(ctx context.Context, accountDataLen uint64) (uint64, error) {
+	return s.GetMinimumBalanceForRentExemptionWithConfig(ctx, accountDataLen, GetMinimumBalanceForRentExemptionConfig{})
+}
+
+func (s *Client) GetMinimumBalanceForRentExemptionWithConfig(ctx context.Context, accountDataLen uint64, cfg GetMinimumBalanceForRentExemptionConfig) (uint64, error) {
 	res := struct {
 		GeneralResponse
 		Result uint64 `json:"result"`
 	}{}
-	err := s.request(ctx, "getMinimumBalanceForRentExemption", []interface{}{accountDataLen}, &res)
+	err := s.request(ctx, "getMinimumBalanceForRentExemption", []interface{}{accountDataLen, cfg}, &res)
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -1,24 +1,28 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
+
+type GetMinDelegationAmountConfig struct {
+	Commitment Commitment `json:"commitment,omitempty"`
+}
 
 func (s *Client) GetMinDelegationAmount(ctx context.Context) (uint64, error) {
+	res, err := s.GetMinDelegationAmountAndContext(ctx, GetMinDelegationAmountConfig{})
+	if err != nil {
+		return 0, err
+	}
+	return res.Value, nil
+}
+
+// GetMinDelegationAmountAndContext returns the stake minimum delegation with the slot it was read at
+func (s *Client) GetMinDelegationAmountAndContext(ctx context.Context, cfg GetMinDelegationAmountConfig) (ValueWithContext[uint64], error) {
 	res := struct {
 		GeneralResponse
-		Result struct {
-			Value uint64 `json:"value"`
-		} `json:"result"`
+		Result ValueWithContext[uint64] `json:"result"`
 	}{}
-
-	err := s.request(ctx, "getStakeMinimumDelegation", []interface{}{}, &res)
+	err := s.request(ctx, "getStakeMinimumDelegation", []interface{}{cfg}, &res)
 	if err != nil {
-		return 0, err
-	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
+		return ValueWithContext[uint64]{}, err
 	}
-	return res.Result.Value, nil
+	return res.Result, nil
 }
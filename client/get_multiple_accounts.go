@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stafiprotocol/solana-go-sdk/lsdprog"
+	"github.com/stafiprotocol/solana-go-sdk/rsolprog"
+	"github.com/stafiprotocol/solana-go-sdk/tokenprog"
+	"golang.org/x/sync/errgroup"
+)
+
+// MaxMultipleAccounts is the number of keys getMultipleAccounts accepts in one call
+const MaxMultipleAccounts = 100
+
+// multipleAccountsConcurrency bounds the chunks requested at the same time
+const multipleAccountsConcurrency = 5
+
+// GetMultipleAccounts returns the accounts in the order of accounts, with nil for accounts not found.
+// Any number of accounts is accepted, they are requested concurrently in chunks of MaxMultipleAccounts.
+func (s *Client) GetMultipleAccounts(ctx context.Context, accounts []string, cfg GetAccountInfoConfig) ([]*GetAccountInfoResponse, error) {
+	ret := make([]*GetAccountInfoResponse, len(accounts))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(multipleAccountsConcurrency)
+	for start := 0; start < len(accounts); start += MaxMultipleAccounts {
+		start := start
+		end := start + MaxMultipleAccounts
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+		g.Go(func() error {
+			values, err := s.getMultipleAccounts(gctx, accounts[start:end], cfg)
+			if err != nil {
+				return err
+			}
+			copy(ret[start:end], values)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *Client) getMultipleAccounts(ctx context.Context, accounts []string, cfg GetAccountInfoConfig) ([]*GetAccountInfoResponse, error) {
+	res := struct {
+		GeneralResponse
+		Result struct {
+			Context Context                   `json:"context"`
+			Value   []*GetAccountInfoResponse `json:"value"`
+		} `json:"result"`
+	}{}
+	err := s.request(ctx, "getMultipleAccounts", []interface{}{accounts, cfg}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Result.Value) != len(accounts) {
+		return nil, fmt.Errorf("getMultipleAccounts returns %d accounts, want %d", len(res.Result.Value), len(accounts))
+	}
+	return res.Result.Value, nil
+}
+
+// getMultipleDecoded fetches accounts with cfg and decodes each one found, missing accounts stay nil
+func getMultipleDecoded[T any](ctx context.Context, s *Client, accounts []string, cfg GetAccountInfoConfig, decode func(GetAccountInfoResponse) (*T, error)) ([]*T, error) {
+	accountInfos, err := s.GetMultipleAccounts(ctx, accounts, cfg)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*T, len(accountInfos))
+	for i, accountInfo := range accountInfos {
+		if accountInfo == nil {
+			continue
+		}
+		ret[i], err = decode(*accountInfo)
+		if err != nil {
+			return nil, fmt.Errorf("decode account %s err: %w", accounts[i], err)
+		}
+	}
+	return ret, nil
+}
+
+func (s *Client) GetMultipleStakeAccounts(ctx context.Context, accounts []string) ([]*StakeAccountRsp, error) {
+	return getMultipleDecoded(ctx, s, accounts, GetStakeAccountInfoConfigDefault, decodeStakeAccount)
+}
+
+func (s *Client) GetMultipleTokenAccounts(ctx context.Context, accounts []string) ([]*tokenprog.TokenAccount, error) {
+	return getMultipleDecoded(ctx, s, accounts, GetTokenAccountInfoCfgDefault, decodeTokenAccount)
+}
+
+func (s *Client) GetMultipleLsdStakeManagers(ctx context.Context, accounts []string) ([]*lsdprog.StakeManager, error) {
+	return getMultipleDecoded(ctx, s, accounts, GetLsdStakeManagerCfgDefault, decodeLsdStakeManager)
+}
+
+func (s *Client) GetMultipleLsdUnstakeAccounts(ctx context.Context, accounts []string) ([]*lsdprog.UnstakeAccount, error) {
+	return getMultipleDecoded(ctx, s, accounts, GetLsdUnstakeAccountCfgDefault, decodeAccountWith(AnchorDecoder[lsdprog.UnstakeAccount]))
+}
+
+func (s *Client) GetMultipleStakeManagers(ctx context.Context, accounts []string) ([]*rsolprog.StakeManager, error) {
+	return getMultipleDecoded(ctx, s, accounts, GetStakeManagerCfgDefault, decodeStakeManager)
+}
+
+func (s *Client) GetMultipleUnstakeAccounts(ctx context.Context, accounts []string) ([]*rsolprog.UnstakeAccount, error) {
+	return getMultipleDecoded(ctx, s, accounts, GetUnstakeAccountCfgDefault, decodeAccountWith(AnchorDecoder[rsolprog.UnstakeAccount]))
+}
+
+var GetUnstakeAccountCfgDefault = GetAccountInfoConfig{
+	Encoding: GetAccountInfoConfigEncodingBase64,
+	DataSlice: GetAccountInfoConfigDataSlice{
+		Offset: 0,
+		Length: rsolprog.UnstakeAccountLengthDefault,
+	},
+}
+
+var GetLsdUnstakeAccountCfgDefault = GetAccountInfoConfig{
+	Encoding: GetAccountInfoConfigEncodingBase64,
+	DataSlice: GetAccountInfoConfigDataSlice{
+		Offset: 0,
+		Length: lsdprog.UnstakeAccountLengthDefault,
+	},
+}
@@ -0,0 +1,68 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func TestGetMultipleAccountsChunks(t *testing.T) {
+	c, fake := newFakeClient(t, nil)
+	fake.handle("getMultipleAccounts", func(params []json.RawMessage) string {
+		keys := []string{}
+		if err := json.Unmarshal(params[0], &keys); err != nil {
+			return rpcError(`{"code":-32602,"message":"Invalid params"}`)
+		}
+		if len(keys) > client.MaxMultipleAccounts {
+			return rpcError(`{"code":-32602,"message":"Too many inputs provided; max 100"}`)
+		}
+		values := make([]string, 0, len(keys))
+		for _, key := range keys {
+			// odd keys are missing, the owner echoes the key to check the order
+			if strings.HasSuffix(key, "1") || strings.HasSuffix(key, "3") {
+				values = append(values, "null")
+				continue
+			}
+			values = append(values, `{"lamports":1,"owner":"`+key+`","executable":false,"rentEpoch":0,"data":["","base64"]}`)
+		}
+		return rpcResult(`{"context":{"slot":1},"value":[` + strings.Join(values, ",") + `]}`)
+	})
+
+	keys := make([]string, 0, 250)
+	for i := 0; i < 250; i++ {
+		keys = append(keys, strings.Repeat("x", i%7)+string(rune('0'+i%4)))
+	}
+	accounts, err := c.GetMultipleAccounts(context.Background(), keys, client.GetAccountInfoConfig{Encoding: client.GetAccountInfoConfigEncodingBase64})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls := fake.callCount("getMultipleAccounts"); calls != 3 {
+		t.Fatalf("calls = %d, want 3", calls)
+	}
+	if len(accounts) != len(keys) {
+		t.Fatalf("accounts = %d, want %d", len(accounts), len(keys))
+	}
+	for i, account := range accounts {
+		missing := i%4 == 1 || i%4 == 3
+		if missing != (account == nil) {
+			t.Fatalf("account %d missing = %v", i, account == nil)
+		}
+		if account != nil && account.Owner != keys[i] {
+			t.Fatalf("account %d owner = %s, want %s", i, account.Owner, keys[i])
+		}
+	}
+}
+
+func TestGetMultipleLsdUnstakeAccountsDataSlice(t *testing.T) {
+	c, fake := newFakeClient(t, map[string]string{"getMultipleAccounts": `{"context":{"slot":1},"value":[null]}`})
+	if _, err := c.GetMultipleLsdUnstakeAccounts(context.Background(), []string{"11111111111111111111111111111111"}); err != nil {
+		t.Fatal(err)
+	}
+	// discriminator, stake manager, recipient, amount and created epoch
+	if got := string(fake.lastParams()[1]); got != `{"encoding":"base64","dataSlice":{"offset":0,"length":88}}` {
+		t.Fatalf("config = %s", got)
+	}
+}
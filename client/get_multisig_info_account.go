@@ -2,10 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/base64"
-	"fmt"
-
-	"github.com/near/borsh-go"
 )
 
 type GetMultisigInfoAccountInfo struct {
@@ -25,35 +21,5 @@ var GetMultsigInfoAccountInfoCfgDefault = GetAccountInfoConfig{
 }
 
 func (s *Client) GetMultisigInfoAccountInfo(ctx context.Context, account string) (*GetMultisigInfoAccountInfo, error) {
-	accountInfo, err := s.GetAccountInfo(ctx, account, GetMultsigInfoAccountInfoCfgDefault)
-	if err != nil {
-		return nil, err
-	}
-
-	accountDataInterface, ok := accountInfo.Data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("account data err")
-	}
-	if len(accountDataInterface) != 2 {
-		return nil, fmt.Errorf("account data length err")
-	}
-	accountDataBase64, ok := accountDataInterface[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("get account base64 failed")
-	}
-
-	accountDataBts, err := base64.StdEncoding.DecodeString(accountDataBase64)
-	if err != nil {
-		return nil, err
-	}
-	if len(accountDataBts) <= 8 {
-		return nil, fmt.Errorf("no account data bytes")
-	}
-
-	multiInfoAccountInfo := GetMultisigInfoAccountInfo{}
-	err = borsh.Deserialize(&multiInfoAccountInfo, accountDataBts[8:])
-	if err != nil {
-		return nil, fmt.Errorf("deserialize err: %s", err)
-	}
-	return &multiInfoAccountInfo, nil
+	return GetAccountAs(ctx, s, account, GetMultsigInfoAccountInfoCfgDefault, AnchorDecoder[GetMultisigInfoAccountInfo])
 }
@@ -2,10 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/base64"
-	"fmt"
-
-	"github.com/near/borsh-go"
 )
 
 type GetMultisigTxAccountInfo struct {
@@ -34,36 +30,5 @@ var GetMultsigTxAccountInfoCfgDefault = GetAccountInfoConfig{
 }
 
 func (s *Client) GetMultisigTxAccountInfo(ctx context.Context, account string) (*GetMultisigTxAccountInfo, error) {
-
-	accountInfo, err := s.GetAccountInfo(ctx, account, GetMultsigTxAccountInfoCfgDefault)
-	if err != nil {
-		return nil, err
-	}
-
-	accountDataInterface, ok := accountInfo.Data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("account data err")
-	}
-	if len(accountDataInterface) != 2 {
-		return nil, fmt.Errorf("account data length err")
-	}
-	accountDataBase64, ok := accountDataInterface[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("get account base64 failed")
-	}
-
-	accountDataBts, err := base64.StdEncoding.DecodeString(accountDataBase64)
-	if err != nil {
-		return nil, err
-	}
-	if len(accountDataBts) <= 8 {
-		return nil, fmt.Errorf("no account data bytes")
-	}
-
-	multiTxAccountInfo := GetMultisigTxAccountInfo{}
-	err = borsh.Deserialize(&multiTxAccountInfo, accountDataBts[8:])
-	if err != nil {
-		return nil, fmt.Errorf("deserialize err: %s", err)
-	}
-	return &multiTxAccountInfo, nil
+	return GetAccountAs(ctx, s, account, GetMultsigTxAccountInfoCfgDefault, AnchorDecoder[GetMultisigTxAccountInfo])
 }
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stafiprotocol/solana-go-sdk/sysprog"
+)
+
+var ErrNonceAccountUninitialized = errors.New("nonce account is not initialized")
+
+var GetNonceAccountCfgDefault = GetAccountInfoConfig{
+	Encoding: GetAccountInfoConfigEncodingBase64,
+	DataSlice: GetAccountInfoConfigDataSlice{
+		Offset: 0,
+		Length: sysprog.NonceAccountSize,
+	},
+}
+
+// GetNonceAccount returns the state of an initialized nonce account, its Nonce is the
+// blockhash of a durable transaction, see sysprog.NewDurableNonceMessage
+func (s *Client) GetNonceAccount(ctx context.Context, account string) (*sysprog.NonceAccount, error) {
+	accountInfo, err := s.GetAccountInfo(ctx, account, GetNonceAccountCfgDefault)
+	if err != nil {
+		return nil, err
+	}
+	return decodeNonceAccount(accountInfo)
+}
+
+func decodeNonceAccount(accountInfo GetAccountInfoResponse) (*sysprog.NonceAccount, error) {
+	accountDataBts, err := accountDataBytes(accountInfo)
+	if err != nil {
+		return nil, err
+	}
+	nonceAccount, err := sysprog.NonceAccountDeserialize(accountDataBts)
+	if err != nil {
+		return nil, err
+	}
+	if !nonceAccount.IsInitialized() {
+		return nil, ErrNonceAccountUninitialized
+	}
+	return &nonceAccount, nil
+}
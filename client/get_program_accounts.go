@@ -3,11 +3,20 @@ package client
 import "context"
 
 type GetProgramAccountsConfig struct {
-	Commitment  *Commitment                    `json:"commitment,omitempty"` // "processed" is not supported. If parameter not provided, the default is "finalized".
-	Encoding    GetAccountInfoConfigEncoding   `json:"encoding"`
-	DataSlice   *GetAccountInfoConfigDataSlice `json:"dataSlice,omitempty"`
-	Filters     []interface{}                  `json:"filters,omitempty"`
-	WithContext bool                           `json:"withContext,omitempty"`
+	Commitment     *Commitment                    `json:"commitment,omitempty"` // "processed" is not supported. If parameter not provided, the default is "finalized".
+	MinContextSlot uint64                         `json:"minContextSlot,omitempty"`
+	Encoding       GetAccountInfoConfigEncoding   `json:"encoding"`
+	DataSlice      *GetAccountInfoConfigDataSlice `json:"dataSlice,omitempty"`
+	Filters        []interface{}                  `json:"filters,omitempty"`
+	// Deprecated: ignored, GetProgramAccountsAndContext asks for the context and
+	// GetProgramAccounts never does
+	WithContext bool `json:"-"`
+}
+
+// getProgramAccountsContextConfig asks the node to return the accounts with the context
+type getProgramAccountsContextConfig struct {
+	GetProgramAccountsConfig
+	WithContext bool `json:"withContext"`
 }
 
 type Memcmp struct {
@@ -36,3 +45,16 @@ func (s *Client) GetProgramAccounts(ctx context.Context, programId string, cfg G
 
 	return res.Result, nil
 }
+
+// GetProgramAccountsAndContext returns the program accounts with the slot they were read at
+func (s *Client) GetProgramAccountsAndContext(ctx context.Context, programId string, cfg GetProgramAccountsConfig) (ValueWithContext[[]GetProgramAccountsResponse], error) {
+	res := struct {
+		GeneralResponse
+		Result ValueWithContext[[]GetProgramAccountsResponse] `json:"result"`
+	}{}
+	err := s.request(ctx, "getProgramAccounts", []interface{}{programId, getProgramAccountsContextConfig{cfg, true}}, &res)
+	if err != nil {
+		return ValueWithContext[[]GetProgramAccountsResponse]{}, err
+	}
+	return res.Result, nil
+}
@@ -0,0 +1,29 @@
+package client
+
+import "context"
+
+// MaxPrioritizationFeeAccounts is the most accounts getRecentPrioritizationFees accepts
+const MaxPrioritizationFeeAccounts = 128
+
+type GetRecentPrioritizationFeesResponse struct {
+	Slot              uint64 `json:"slot"`
+	PrioritizationFee uint64 `json:"prioritizationFee"` // micro-lamports per compute unit
+}
+
+// GetRecentPrioritizationFees returns the minimum fee paid by a landed transaction in each of
+// the recent slots the node keeps, locking all the given writable accounts if any are given
+func (s *Client) GetRecentPrioritizationFees(ctx context.Context, addresses []string) ([]GetRecentPrioritizationFeesResponse, error) {
+	res := struct {
+		GeneralResponse
+		Result []GetRecentPrioritizationFeesResponse `json:"result"`
+	}{}
+	params := []interface{}{}
+	if len(addresses) > 0 {
+		params = append(params, addresses)
+	}
+	err := s.request(ctx, "getRecentPrioritizationFees", params, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetSignaturesForAddress struct {
 	Signature string      `json:"signature"`
@@ -14,10 +11,11 @@ type GetSignaturesForAddress struct {
 }
 
 type GetSignaturesForAddressConfig struct {
-	Limit      int        `json:"limit,omitempty"` // between 1 and 1000, default: 1000
-	Before     string     `json:"before,omitempty"`
-	Until      string     `json:"until,omitempty"`
-	Commitment Commitment `json:"commitment,omitempty"` // "processed" is not supported, default is "finalized"
+	Limit          int        `json:"limit,omitempty"` // between 1 and 1000, default: 1000
+	Before         string     `json:"before,omitempty"`
+	Until          string     `json:"until,omitempty"`
+	Commitment     Commitment `json:"commitment,omitempty"` // "processed" is not supported, default is "finalized"
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
 }
 
 // NEW: This method is only available in solana-core v1.7 or newer. Please use "getConfirmedSignaturesForAddress2" for solana-core v1.6
@@ -32,8 +30,5 @@ func (s *Client) GetSignaturesForAddress(ctx context.Context, base58Addr string,
 	if err != nil {
 		return []GetSignaturesForAddress{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return []GetSignaturesForAddress{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetSignatureStatusesResponse struct {
 	Slot               uint64      `json:"slot"`
@@ -13,19 +10,22 @@ type GetSignatureStatusesResponse struct {
 }
 
 func (s *Client) GetSignatureStatuses(ctx context.Context, signatures []string) ([]GetSignatureStatusesResponse, error) {
+	res, err := s.GetSignatureStatusesAndContext(ctx, signatures)
+	if err != nil {
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+// GetSignatureStatusesAndContext returns the signature statuses with the slot they were read at
+func (s *Client) GetSignatureStatusesAndContext(ctx context.Context, signatures []string) (ValueWithContext[[]GetSignatureStatusesResponse], error) {
 	res := struct {
 		GeneralResponse
-		Result struct {
-			Context Context                        `json:"context"`
-			Value   []GetSignatureStatusesResponse `json:"value"`
-		} `json:"result"`
+		Result ValueWithContext[[]GetSignatureStatusesResponse] `json:"result"`
 	}{}
 	err := s.request(ctx, "getSignatureStatuses", []interface{}{signatures, map[string]interface{}{"searchTransactionHistory": true}}, &res)
 	if err != nil {
-		return nil, err
-	}
-	if res.Error != (ErrorResponse{}) {
-		return nil, errors.New(res.Error.Message)
+		return ValueWithContext[[]GetSignatureStatusesResponse]{}, err
 	}
-	return res.Result.Value, nil
+	return res.Result, nil
 }
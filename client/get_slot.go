@@ -1,12 +1,10 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetSlotConfig struct {
-	Commitment Commitment `json:"commitment,omitempty"`
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
 }
 
 // GetSlob returns the current slot  of the node
@@ -19,8 +17,5 @@ func (s *Client) GetSlot(ctx context.Context, cfg GetSlotConfig) (uint64, error)
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"math"
 
@@ -222,20 +221,11 @@ func (s *Client) GetStakeAccountInfo(ctx context.Context, account string) (*Stak
 	if err != nil {
 		return nil, err
 	}
+	return decodeStakeAccount(accountInfo)
+}
 
-	accountDataInterface, ok := accountInfo.Data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("account data err")
-	}
-	if len(accountDataInterface) != 2 {
-		return nil, fmt.Errorf("account data length err")
-	}
-	accountDataBase64, ok := accountDataInterface[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("get account base64 failed")
-	}
-
-	accountDataBts, err := base64.StdEncoding.DecodeString(accountDataBase64)
+func decodeStakeAccount(accountInfo GetAccountInfoResponse) (*StakeAccountRsp, error) {
+	accountDataBts, err := accountDataBytes(accountInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -251,7 +241,7 @@ func (s *Client) GetStakeAccountInfo(ctx context.Context, account string) (*Stak
 	rsp := StakeAccountRsp{
 		Lamports:     accountInfo.Lamports,
 		Owner:        accountInfo.Owner,
-		Excutable:    accountInfo.Excutable,
+		Excutable:    accountInfo.Executable,
 		RentEpoch:    accountInfo.RentEpoch,
 		StakeAccount: stakeAccountInfo,
 	}
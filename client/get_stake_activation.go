@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type StakeActivationState string
 
@@ -15,8 +12,9 @@ const (
 )
 
 type GetStakeActivationConfig struct {
-	Commitment Commitment `json:"commitment,omitempty"`
-	Epoch      uint64     `json:"epoch,omitempty"`
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
+	Epoch          uint64     `json:"epoch,omitempty"`
 }
 
 type GetStakeActivationResponse struct {
@@ -35,9 +33,5 @@ func (s *Client) GetStakeActivation(ctx context.Context, address string, cfg Get
 	if err != nil {
 		return GetStakeActivationResponse{}, err
 	}
-	//rpc error
-	if res.Error != (ErrorResponse{}) {
-		return GetStakeActivationResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 
 	"github.com/stafiprotocol/solana-go-sdk/binary"
@@ -41,19 +40,7 @@ func (s *Client) GetStakeHistory(ctx context.Context) (*StakeHistoryRsp, error)
 		return nil, err
 	}
 
-	accountDataInterface, ok := accountInfo.Data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("account data err")
-	}
-	if len(accountDataInterface) != 2 {
-		return nil, fmt.Errorf("account data length err")
-	}
-	accountDataBase64, ok := accountDataInterface[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("get account base64 failed")
-	}
-
-	accountDataBts, err := base64.StdEncoding.DecodeString(accountDataBase64)
+	accountDataBts, err := accountDataBytes(accountInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +56,7 @@ func (s *Client) GetStakeHistory(ctx context.Context) (*StakeHistoryRsp, error)
 	rsp := StakeHistoryRsp{
 		Lamports:       accountInfo.Lamports,
 		Owner:          accountInfo.Owner,
-		Excutable:      accountInfo.Excutable,
+		Excutable:      accountInfo.Executable,
 		RentEpoch:      accountInfo.RentEpoch,
 		StakeHistories: shy,
 	}
@@ -2,10 +2,7 @@ package client
 
 import (
 	"context"
-	"encoding/base64"
-	"fmt"
 
-	"github.com/near/borsh-go"
 	"github.com/stafiprotocol/solana-go-sdk/rsolprog"
 )
 
@@ -22,31 +19,9 @@ func (s *Client) GetStakeManager(ctx context.Context, account string) (*rsolprog
 	if err != nil {
 		return nil, err
 	}
+	return decodeStakeManager(accountInfo)
+}
 
-	accountDataInterface, ok := accountInfo.Data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("account data err")
-	}
-	if len(accountDataInterface) != 2 {
-		return nil, fmt.Errorf("account data length err")
-	}
-	accountDataBase64, ok := accountDataInterface[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("get account base64 failed")
-	}
-
-	accountDataBts, err := base64.StdEncoding.DecodeString(accountDataBase64)
-	if err != nil {
-		return nil, err
-	}
-	if len(accountDataBts) <= 8 {
-		return nil, fmt.Errorf("no account data bytes")
-	}
-
-	stakeManager := rsolprog.StakeManager{}
-	err = borsh.Deserialize(&stakeManager, accountDataBts[8:])
-	if err != nil {
-		return nil, fmt.Errorf("deserialize err: %s", err.Error())
-	}
-	return &stakeManager, nil
+func decodeStakeManager(accountInfo GetAccountInfoResponse) (*rsolprog.StakeManager, error) {
+	return decodeAccountWith(AnchorDecoder[rsolprog.StakeManager])(accountInfo)
 }
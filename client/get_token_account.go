@@ -2,8 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/base64"
-	"fmt"
 
 	"github.com/stafiprotocol/solana-go-sdk/tokenprog"
 )
@@ -17,28 +15,21 @@ var GetTokenAccountInfoCfgDefault = GetAccountInfoConfig{
 }
 
 func (s *Client) GetTokenAccountInfo(ctx context.Context, account string) (*tokenprog.TokenAccount, error) {
-
-	accountInfo, err := s.GetAccountInfo(ctx, account, GetMultsigTxAccountInfoCfgDefault)
+	accountInfo, err := s.GetAccountInfo(ctx, account, GetTokenAccountInfoCfgDefault)
 	if err != nil {
 		return nil, err
 	}
+	return decodeTokenAccount(accountInfo)
+}
 
-	accountDataInterface, ok := accountInfo.Data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("account data err")
-	}
-	if len(accountDataInterface) != 2 {
-		return nil, fmt.Errorf("account data length err")
-	}
-	accountDataBase64, ok := accountDataInterface[0].(string)
-	if !ok {
-		return nil, fmt.Errorf("get account base64 failed")
-	}
-
-	accountDataBts, err := base64.StdEncoding.DecodeString(accountDataBase64)
+func decodeTokenAccount(accountInfo GetAccountInfoResponse) (*tokenprog.TokenAccount, error) {
+	accountDataBts, err := accountDataBytes(accountInfo)
 	if err != nil {
 		return nil, err
 	}
-
-	return tokenprog.TokenAccountFromData(accountDataBts[:])
+	// token-2022 accounts append extensions to the token program layout
+	if len(accountDataBts) > tokenprog.TokenAccountSize {
+		accountDataBts = accountDataBts[:tokenprog.TokenAccountSize]
+	}
+	return tokenprog.TokenAccountFromData(accountDataBts)
 }
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"strconv"
+)
+
+// TokenAmount is a raw token amount with the decimals of its mint
+type TokenAmount struct {
+	Amount         string   `json:"amount"`
+	Decimals       uint8    `json:"decimals"`
+	UiAmount       *float64 `json:"uiAmount"` // deprecated by the rpc, nil if it can't be represented
+	UiAmountString string   `json:"uiAmountString"`
+}
+
+// AmountUint64 parses the raw amount
+func (a TokenAmount) AmountUint64() (uint64, error) {
+	return strconv.ParseUint(a.Amount, 10, 64)
+}
+
+type GetTokenConfig struct {
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
+}
+
+func (s *Client) GetTokenAccountBalance(ctx context.Context, account string, cfg GetTokenConfig) (TokenAmount, error) {
+	res, err := s.GetTokenAccountBalanceAndContext(ctx, account, cfg)
+	if err != nil {
+		return TokenAmount{}, err
+	}
+	return res.Value, nil
+}
+
+// GetTokenAccountBalanceAndContext returns the balance with the slot it was read at
+func (s *Client) GetTokenAccountBalanceAndContext(ctx context.Context, account string, cfg GetTokenConfig) (ValueWithContext[TokenAmount], error) {
+	res := struct {
+		GeneralResponse
+		Result ValueWithContext[TokenAmount] `json:"result"`
+	}{}
+	err := s.request(ctx, "getTokenAccountBalance", []interface{}{account, cfg}, &res)
+	if err != nil {
+		return ValueWithContext[TokenAmount]{}, err
+	}
+	return res.Result, nil
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stafiprotocol/solana-go-sdk/tokenprog"
+)
+
+// TokenAccountsFilter selects the token accounts of one mint or of one token program,
+// exactly one of the fields must be set
+type TokenAccountsFilter struct {
+	Mint      string `json:"mint,omitempty"`
+	ProgramId string `json:"programId,omitempty"`
+}
+
+type GetTokenAccountsConfig struct {
+	Commitment     Commitment `json:"commitment,omitempty"`
+	MinContextSlot uint64     `json:"minContextSlot,omitempty"`
+}
+
+type getTokenAccountsConfig struct {
+	GetTokenAccountsConfig
+	Encoding GetAccountInfoConfigEncoding `json:"encoding"`
+}
+
+type GetTokenAccountsResponse struct {
+	Pubkey       string
+	Account      GetAccountInfoResponse
+	TokenAccount tokenprog.TokenAccount
+}
+
+// GetTokenAccountsByOwner returns the token accounts owned by owner
+func (s *Client) GetTokenAccountsByOwner(ctx context.Context, owner string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) ([]GetTokenAccountsResponse, error) {
+	res, err := s.GetTokenAccountsByOwnerAndContext(ctx, owner, filter, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+// GetTokenAccountsByOwnerAndContext returns the token accounts owned by owner with the slot they were read at
+func (s *Client) GetTokenAccountsByOwnerAndContext(ctx context.Context, owner string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) (ValueWithContext[[]GetTokenAccountsResponse], error) {
+	return s.getTokenAccounts(ctx, "getTokenAccountsByOwner", owner, filter, cfg)
+}
+
+// GetTokenAccountsByDelegate returns the token accounts delegated to delegate
+func (s *Client) GetTokenAccountsByDelegate(ctx context.Context, delegate string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) ([]GetTokenAccountsResponse, error) {
+	res, err := s.GetTokenAccountsByDelegateAndContext(ctx, delegate, filter, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+// GetTokenAccountsByDelegateAndContext returns the token accounts delegated to delegate with the slot they were read at
+func (s *Client) GetTokenAccountsByDelegateAndContext(ctx context.Context, delegate string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) (ValueWithContext[[]GetTokenAccountsResponse], error) {
+	return s.getTokenAccounts(ctx, "getTokenAccountsByDelegate", delegate, filter, cfg)
+}
+
+func (s *Client) getTokenAccounts(ctx context.Context, method, address string, filter TokenAccountsFilter, cfg GetTokenAccountsConfig) (ValueWithContext[[]GetTokenAccountsResponse], error) {
+	if (filter.Mint == "") == (filter.ProgramId == "") {
+		return ValueWithContext[[]GetTokenAccountsResponse]{}, errors.New("token accounts filter needs exactly one of mint and programId")
+	}
+	res := struct {
+		GeneralResponse
+		Result ValueWithContext[[]GetProgramAccountsResponse] `json:"result"`
+	}{}
+	err := s.request(ctx, method, []interface{}{address, filter, getTokenAccountsConfig{
+		GetTokenAccountsConfig: cfg,
+		Encoding:               GetAccountInfoConfigEncodingBase64,
+	}}, &res)
+	if err != nil {
+		return ValueWithContext[[]GetTokenAccountsResponse]{}, err
+	}
+
+	ret := make([]GetTokenAccountsResponse, 0, len(res.Result.Value))
+	for _, account := range res.Result.Value {
+		tokenAccount, err := decodeTokenAccount(account.Account)
+		if err != nil {
+			return ValueWithContext[[]GetTokenAccountsResponse]{}, fmt.Errorf("decode token account %s err: %w", account.Pubkey, err)
+		}
+		ret = append(ret, GetTokenAccountsResponse{
+			Pubkey:       account.Pubkey,
+			Account:      account.Account,
+			TokenAccount: *tokenAccount,
+		})
+	}
+	return ValueWithContext[[]GetTokenAccountsResponse]{Context: res.Result.Context, Value: ret}, nil
+}
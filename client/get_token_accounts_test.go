@@ -0,0 +1,133 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/tokenprog"
+)
+
+func TestTokenQueries(t *testing.T) {
+	mint := common.PublicKeyFromString("7hUdUTkJLwdcmt3jSEeqx4ep91sm1XwBxMDaJae6bD5D")
+	owner := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	data := make([]byte, tokenprog.TokenAccountSize)
+	copy(data[0:32], mint.Bytes())
+	copy(data[32:64], owner.Bytes())
+	binary.LittleEndian.PutUint64(data[64:72], 1500000000)
+	data[108] = byte(tokenprog.TokenAccountStateInitialized)
+	tokenAccount := `{"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","executable":false,"rentEpoch":0,"space":165,"data":["` +
+		base64.StdEncoding.EncodeToString(data) + `","base64"]}`
+	amount := `"amount":"1500000000","decimals":9,"uiAmount":1.5,"uiAmountString":"1.5"`
+
+	values := map[string]string{
+		"getTokenAccountsByOwner":    `[{"pubkey":"DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi","account":` + tokenAccount + `}]`,
+		"getTokenAccountsByDelegate": `[{"pubkey":"DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi","account":` + tokenAccount + `}]`,
+		"getTokenAccountBalance":     `{` + amount + `}`,
+		"getTokenSupply":             `{` + amount + `}`,
+		"getTokenLargestAccounts":    `[{"address":"DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi",` + amount + `}]`,
+	}
+	results := make(map[string]string, len(values))
+	for method, value := range values {
+		results[method] = `{"context":{"slot":7},"value":` + value + `}`
+	}
+	c, fake := newFakeClient(t, results)
+	ctx := context.Background()
+
+	accounts, err := c.GetTokenAccountsByOwner(ctx, owner.ToBase58(), client.TokenAccountsFilter{Mint: mint.ToBase58()}, client.GetTokenAccountsConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 1 || accounts[0].TokenAccount.Mint != mint || accounts[0].TokenAccount.Owner != owner || accounts[0].TokenAccount.Amount != 1500000000 {
+		t.Fatalf("accounts = %+v", accounts)
+	}
+	params := fake.lastParams()
+	if string(params[1]) != `{"mint":"`+mint.ToBase58()+`"}` || string(params[2]) != `{"encoding":"base64"}` {
+		t.Fatalf("params = %s %s", params[1], params[2])
+	}
+
+	_, err = c.GetTokenAccountsByDelegate(ctx, owner.ToBase58(), client.TokenAccountsFilter{ProgramId: common.TokenProgramID.ToBase58()}, client.GetTokenAccountsConfig{Commitment: client.CommitmentConfirmed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	params = fake.lastParams()
+	if string(params[2]) != `{"commitment":"confirmed","encoding":"base64"}` {
+		t.Fatalf("config = %s", params[2])
+	}
+
+	for _, filter := range []client.TokenAccountsFilter{{}, {Mint: mint.ToBase58(), ProgramId: common.TokenProgramID.ToBase58()}} {
+		if _, err := c.GetTokenAccountsByOwner(ctx, owner.ToBase58(), filter, client.GetTokenAccountsConfig{}); err == nil {
+			t.Fatalf("filter %+v want err", filter)
+		}
+	}
+
+	balance, err := c.GetTokenAccountBalance(ctx, "DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi", client.GetTokenConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := balance.AmountUint64(); err != nil || n != 1500000000 || balance.Decimals != 9 || balance.UiAmountString != "1.5" {
+		t.Fatalf("balance = %+v", balance)
+	}
+
+	supply, err := c.GetTokenSupply(ctx, mint.ToBase58(), client.GetTokenConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if supply.UiAmount == nil || *supply.UiAmount != 1.5 {
+		t.Fatalf("supply = %+v", supply)
+	}
+	// a zero config must not pin the node to slot 0
+	if params := fake.lastParams(); string(params[1]) != `{}` {
+		t.Fatalf("config = %s", params[1])
+	}
+
+	largest, err := c.GetTokenLargestAccounts(ctx, mint.ToBase58(), client.GetTokenConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(largest) != 1 || largest[0].Address != "DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi" || largest[0].Amount != "1500000000" {
+		t.Fatalf("largest = %+v", largest)
+	}
+	if params := fake.lastParams(); string(params[1]) != `{}` {
+		t.Fatalf("config = %s", params[1])
+	}
+
+	// the AndContext variants keep the slot the value was read at
+	cfg := client.GetTokenConfig{Commitment: client.CommitmentConfirmed, MinContextSlot: 5}
+	balanceAndContext, err := c.GetTokenAccountBalanceAndContext(ctx, "DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balanceAndContext.Context.Slot != 7 || balanceAndContext.Value.Amount != "1500000000" {
+		t.Fatalf("balance = %+v", balanceAndContext)
+	}
+	if params := fake.lastParams(); string(params[1]) != `{"commitment":"confirmed","minContextSlot":5}` {
+		t.Fatalf("config = %s", params[1])
+	}
+	supplyAndContext, err := c.GetTokenSupplyAndContext(ctx, mint.ToBase58(), cfg)
+	if err != nil || supplyAndContext.Context.Slot != 7 {
+		t.Fatalf("supply = %+v, err: %v", supplyAndContext, err)
+	}
+	if params := fake.lastParams(); string(params[1]) != `{"commitment":"confirmed","minContextSlot":5}` {
+		t.Fatalf("config = %s", params[1])
+	}
+	largestAndContext, err := c.GetTokenLargestAccountsAndContext(ctx, mint.ToBase58(), cfg)
+	if err != nil || largestAndContext.Context.Slot != 7 || len(largestAndContext.Value) != 1 {
+		t.Fatalf("largest = %+v, err: %v", largestAndContext, err)
+	}
+	if params := fake.lastParams(); string(params[1]) != `{"commitment":"confirmed","minContextSlot":5}` {
+		t.Fatalf("config = %s", params[1])
+	}
+	filter := client.TokenAccountsFilter{Mint: mint.ToBase58()}
+	ownerAndContext, err := c.GetTokenAccountsByOwnerAndContext(ctx, owner.ToBase58(), filter, client.GetTokenAccountsConfig{})
+	if err != nil || ownerAndContext.Context.Slot != 7 || len(ownerAndContext.Value) != 1 {
+		t.Fatalf("owner accounts = %+v, err: %v", ownerAndContext, err)
+	}
+	delegateAndContext, err := c.GetTokenAccountsByDelegateAndContext(ctx, owner.ToBase58(), filter, client.GetTokenAccountsConfig{})
+	if err != nil || delegateAndContext.Context.Slot != 7 || len(delegateAndContext.Value) != 1 {
+		t.Fatalf("delegate accounts = %+v, err: %v", delegateAndContext, err)
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import "context"
+
+type TokenLargestAccount struct {
+	Address string `json:"address"`
+	TokenAmount
+}
+
+// GetTokenLargestAccounts returns the 20 largest accounts of the mint, largest first
+func (s *Client) GetTokenLargestAccounts(ctx context.Context, mint string, cfg GetTokenConfig) ([]TokenLargestAccount, error) {
+	res, err := s.GetTokenLargestAccountsAndContext(ctx, mint, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+// GetTokenLargestAccountsAndContext returns the largest accounts with the slot they were read at
+func (s *Client) GetTokenLargestAccountsAndContext(ctx context.Context, mint string, cfg GetTokenConfig) (ValueWithContext[[]TokenLargestAccount], error) {
+	res := struct {
+		GeneralResponse
+		Result ValueWithContext[[]TokenLargestAccount] `json:"result"`
+	}{}
+	err := s.request(ctx, "getTokenLargestAccounts", []interface{}{mint, cfg}, &res)
+	if err != nil {
+		return ValueWithContext[[]TokenLargestAccount]{}, err
+	}
+	return res.Result, nil
+}
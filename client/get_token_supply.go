@@ -0,0 +1,24 @@
+package client
+
+import "context"
+
+func (s *Client) GetTokenSupply(ctx context.Context, mint string, cfg GetTokenConfig) (TokenAmount, error) {
+	res, err := s.GetTokenSupplyAndContext(ctx, mint, cfg)
+	if err != nil {
+		return TokenAmount{}, err
+	}
+	return res.Value, nil
+}
+
+// GetTokenSupplyAndContext returns the supply with the slot it was read at
+func (s *Client) GetTokenSupplyAndContext(ctx context.Context, mint string, cfg GetTokenConfig) (ValueWithContext[TokenAmount], error) {
+	res := struct {
+		GeneralResponse
+		Result ValueWithContext[TokenAmount] `json:"result"`
+	}{}
+	err := s.request(ctx, "getTokenSupply", []interface{}{mint, cfg}, &res)
+	if err != nil {
+		return ValueWithContext[TokenAmount]{}, err
+	}
+	return res.Result, nil
+}
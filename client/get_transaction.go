@@ -28,9 +28,15 @@ type GetTransaction struct {
 }
 
 type GetTransactionResponse struct {
-	Slot        uint64          `json:"slot"`
-	Meta        TransactionMeta `json:"meta"`
-	Transaction Transaction     `json:"transaction"`
+	Slot        uint64             `json:"slot"`
+	Meta        TransactionMeta    `json:"meta"`
+	Transaction Transaction        `json:"transaction"`
+	Version     TransactionVersion `json:"version"`
+}
+
+// AccountKeys returns the static and the loaded account keys of the transaction
+func (r GetTransactionResponse) AccountKeys() []string {
+	return AllAccountKeys(r.Transaction, r.Meta)
 }
 
 // NEW: This method is only available in solana-core v1.7 or newer. Please use getConfirmedTransaction for solana-core v1.6
@@ -2,13 +2,10 @@ package client
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/hex"
-	"fmt"
 	"strconv"
 
 	"github.com/mr-tron/base58"
-	"github.com/near/borsh-go"
 	"github.com/stafiprotocol/solana-go-sdk/rsolprog"
 )
 
@@ -43,34 +40,14 @@ func (s *Client) GetUnstakeAccountByEpoch(ctx context.Context, programId string,
 	if err != nil {
 		return nil, err
 	}
+	decode := decodeAccountWith(AnchorDecoder[rsolprog.UnstakeAccount])
 	ret := make([]rsolprog.UnstakeAccount, 0)
 	for _, accountInfo := range accounts {
-		accountDataInterface, ok := accountInfo.Account.Data.([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("account data err")
-		}
-		if len(accountDataInterface) != 2 {
-			return nil, fmt.Errorf("account data length err")
-		}
-		accountDataBase64, ok := accountDataInterface[0].(string)
-		if !ok {
-			return nil, fmt.Errorf("get account base64 failed")
-		}
-
-		accountDataBts, err := base64.StdEncoding.DecodeString(accountDataBase64)
+		unstakeAccount, err := decode(accountInfo.Account)
 		if err != nil {
 			return nil, err
 		}
-		if len(accountDataBts) <= 8 {
-			return nil, fmt.Errorf("no account data bytes")
-		}
-
-		unstakeAccount := rsolprog.UnstakeAccount{}
-		err = borsh.Deserialize(&unstakeAccount, accountDataBts[8:])
-		if err != nil {
-			return nil, fmt.Errorf("deserialize err: %s", err.Error())
-		}
-		ret = append(ret, unstakeAccount)
+		ret = append(ret, *unstakeAccount)
 	}
 	return ret, nil
 }
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type GetVoteAccountsConfig struct {
+	Commitment              Commitment `json:"commitment,omitempty"`
+	VotePubkey              string     `json:"votePubkey,omitempty"` // only return this vote account
+	KeepUnstakedDelinquents bool       `json:"keepUnstakedDelinquents,omitempty"`
+	DelinquentSlotDistance  uint64     `json:"delinquentSlotDistance,omitempty"`
+}
+
+type GetVoteAccountsResponse struct {
+	Current    []VoteAccount `json:"current"`
+	Delinquent []VoteAccount `json:"delinquent"`
+}
+
+type VoteAccount struct {
+	VotePubkey       string         `json:"votePubkey"`
+	NodePubkey       string         `json:"nodePubkey"`
+	ActivatedStake   uint64         `json:"activatedStake"`
+	EpochVoteAccount bool           `json:"epochVoteAccount"`
+	Commission       uint8          `json:"commission"`
+	LastVote         uint64         `json:"lastVote"`
+	RootSlot         uint64         `json:"rootSlot"`
+	EpochCredits     []EpochCredits `json:"epochCredits"` // up to the last 5 epochs
+}
+
+// EpochCredits is the [epoch, credits, previousCredits] triple of a vote account,
+// the credits earned in Epoch are Credits - PreviousCredits
+type EpochCredits struct {
+	Epoch           uint64
+	Credits         uint64
+	PreviousCredits uint64
+}
+
+func (e *EpochCredits) UnmarshalJSON(b []byte) error {
+	triple := []uint64{}
+	if err := json.Unmarshal(b, &triple); err != nil {
+		return err
+	}
+	if len(triple) != 3 {
+		return fmt.Errorf("epoch credits length %d err", len(triple))
+	}
+	*e = EpochCredits{Epoch: triple[0], Credits: triple[1], PreviousCredits: triple[2]}
+	return nil
+}
+
+func (e EpochCredits) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]uint64{e.Epoch, e.Credits, e.PreviousCredits})
+}
+
+func (s *Client) GetVoteAccounts(ctx context.Context, cfg GetVoteAccountsConfig) (GetVoteAccountsResponse, error) {
+	res := struct {
+		GeneralResponse
+		Result GetVoteAccountsResponse `json:"result"`
+	}{}
+	err := s.request(ctx, "getVoteAccounts", []interface{}{cfg}, &res)
+	if err != nil {
+		return GetVoteAccountsResponse{}, err
+	}
+	return res.Result, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/sysprog"
+)
+
+var ErrNoncePoolEmpty = errors.New("nonce pool has no account")
+
+// NoncePool hands out nonce accounts to concurrent senders, an account is leased to one
+// sender at a time so that two transactions never advance the same nonce
+type NoncePool struct {
+	caller RPCCaller
+	free   chan common.PublicKey
+}
+
+// NonceLease is a nonce account held by one sender, Release it once the transaction
+// using it is confirmed or has failed
+type NonceLease struct {
+	Account common.PublicKey
+	Nonce   sysprog.NonceAccount
+
+	pool *NoncePool
+	once sync.Once
+}
+
+func NewNoncePool(caller RPCCaller, accounts []common.PublicKey) *NoncePool {
+	free := make(chan common.PublicKey, len(accounts))
+	for _, account := range accounts {
+		free <- account
+	}
+	return &NoncePool{caller: caller, free: free}
+}
+
+// Acquire waits for a free nonce account and fetches its current nonce
+func (p *NoncePool) Acquire(ctx context.Context) (*NonceLease, error) {
+	if cap(p.free) == 0 {
+		return nil, ErrNoncePoolEmpty
+	}
+	var account common.PublicKey
+	select {
+	case account = <-p.free:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	nonceAccount, err := p.caller.GetNonceAccount(ctx, account.ToBase58())
+	if err != nil {
+		p.free <- account
+		return nil, err
+	}
+	return &NonceLease{Account: account, Nonce: *nonceAccount, pool: p}, nil
+}
+
+// Available returns the number of accounts not leased
+func (p *NoncePool) Available() int {
+	return len(p.free)
+}
+
+// Release returns the account to the pool, later calls are no-ops
+func (l *NonceLease) Release() {
+	l.once.Do(func() {
+		l.pool.free <- l.Account
+	})
+}
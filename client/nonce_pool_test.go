@@ -0,0 +1,105 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/sysprog"
+)
+
+// newNonceClient returns a client whose nonce accounts are all in state
+func newNonceClient(t *testing.T, state uint32) *client.Client {
+	data := make([]byte, sysprog.NonceAccountSize)
+	binary.LittleEndian.PutUint32(data[0:4], sysprog.NonceVersionCurrent)
+	binary.LittleEndian.PutUint32(data[4:8], state)
+	copy(data[40:72], common.PublicKeyFromString("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g").Bytes())
+	binary.LittleEndian.PutUint64(data[72:80], 5000)
+	c, _ := newFakeClient(t, map[string]string{
+		"getAccountInfo": `{"context":{"slot":1},"value":{"lamports":1,"owner":"11111111111111111111111111111111","executable":false,"rentEpoch":0,"data":["` +
+			base64.StdEncoding.EncodeToString(data) + `","base64"]}}`,
+	})
+	return c
+}
+
+func TestGetNonceAccount(t *testing.T) {
+	c := newNonceClient(t, sysprog.NonceStateInitialized)
+	nonceAccount, err := c.GetNonceAccount(context.Background(), "DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonceAccount.Nonce.ToBase58() != "9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g" || nonceAccount.FeeCalculator.LamportsPerSignature != 5000 {
+		t.Fatalf("nonce account = %+v", nonceAccount)
+	}
+
+	c = newNonceClient(t, sysprog.NonceStateUninitialized)
+	_, err = c.GetNonceAccount(context.Background(), "DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi")
+	if !errors.Is(err, client.ErrNonceAccountUninitialized) {
+		t.Fatalf("err = %v, want ErrNonceAccountUninitialized", err)
+	}
+}
+
+func TestNoncePoolLeasesAccountOnce(t *testing.T) {
+	c := newNonceClient(t, sysprog.NonceStateInitialized)
+	accounts := []common.PublicKey{
+		common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi"),
+		common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+	}
+	pool := client.NewNoncePool(c, accounts)
+
+	mu := sync.Mutex{}
+	inUse := map[common.PublicKey]bool{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lease, err := pool.Acquire(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			if inUse[lease.Account] {
+				t.Errorf("%s leased twice", lease.Account.ToBase58())
+			}
+			inUse[lease.Account] = true
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			inUse[lease.Account] = false
+			mu.Unlock()
+			lease.Release()
+			lease.Release()
+		}()
+	}
+	wg.Wait()
+	if pool.Available() != len(accounts) {
+		t.Fatalf("available = %d, want %d", pool.Available(), len(accounts))
+	}
+
+	leases := []*client.NonceLease{}
+	for range accounts {
+		lease, err := pool.Acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		leases = append(leases, lease)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	for _, lease := range leases {
+		lease.Release()
+	}
+}
@@ -0,0 +1,324 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PoolConfig controls how the client chooses between its endpoints
+type PoolConfig struct {
+	// MaxSlotLag quarantines endpoints whose slot is this far behind the highest probed slot, 0 disables the check
+	MaxSlotLag uint64
+	// FailureThreshold is the number of consecutive failures that quarantines an endpoint
+	FailureThreshold int
+	// QuarantineCooldown is how long a bad endpoint is skipped
+	QuarantineCooldown time.Duration
+}
+
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxSlotLag:         50,
+		FailureThreshold:   3,
+		QuarantineCooldown: 30 * time.Second,
+	}
+}
+
+// WithPoolConfig replaces DefaultPoolConfig
+func WithPoolConfig(cfg PoolConfig) Option {
+	return func(c *Client) {
+		c.pool.cfg = cfg
+	}
+}
+
+// EndpointStats is a snapshot of the health of one endpoint
+type EndpointStats struct {
+	Endpoint         string
+	Requests         uint64
+	Failures         uint64
+	ErrorRate        float64       // moving average of failed requests, in [0, 1]
+	Latency          time.Duration // moving average of successful requests
+	Slot             uint64        // slot seen by the last probe
+	SlotLag          uint64        // distance to the highest slot seen by the last probe
+	Quarantined      bool
+	QuarantinedUntil time.Time
+	LastError        string
+}
+
+// weight of the latest sample in the moving averages
+const ewmaAlpha = 0.2
+
+// slotLagPenalty is added to the score of an endpoint for every slot it lags behind
+const slotLagPenalty = 10 * time.Millisecond
+
+type endpointState struct {
+	EndpointStats
+	sampled             bool
+	consecutiveFailures int
+}
+
+func (e *endpointState) available(now time.Time) bool {
+	return !now.Before(e.QuarantinedUntil)
+}
+
+// score is lower for healthier endpoints
+func (e *endpointState) score() float64 {
+	return float64(e.Latency)*(1+10*e.ErrorRate) + float64(e.SlotLag)*float64(slotLagPenalty)
+}
+
+// endpointPool keeps using the current endpoint while it is healthy, and moves to
+// the best scored available endpoint when it is quarantined or clearly outperformed
+type endpointPool struct {
+	mu        sync.Mutex
+	cfg       PoolConfig
+	endpoints []*endpointState
+	current   int
+}
+
+func newEndpointPool(endpointList []string) *endpointPool {
+	endpoints := make([]*endpointState, 0, len(endpointList))
+	for _, endpoint := range endpointList {
+		endpoints = append(endpoints, &endpointState{EndpointStats: EndpointStats{Endpoint: endpoint}})
+	}
+	return &endpointPool{
+		cfg:       DefaultPoolConfig(),
+		endpoints: endpoints,
+	}
+}
+
+func (p *endpointPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	cur := p.endpoints[p.current]
+	best := p.best(now)
+	switch {
+	case best < 0:
+		// every endpoint is quarantined, use the one released first
+		for i, e := range p.endpoints {
+			if e.QuarantinedUntil.Before(p.endpoints[p.current].QuarantinedUntil) {
+				p.current = i
+			}
+		}
+	case !cur.available(now):
+		p.current = best
+	case cur.sampled && p.endpoints[best].sampled && 2*p.endpoints[best].score() < cur.score():
+		p.current = best
+	}
+	return p.endpoints[p.current].Endpoint
+}
+
+// best returns the index of the available endpoint with the lowest score, unsampled
+// endpoints score 0 and ties go to the first one after current. -1 if none is available
+func (p *endpointPool) best(now time.Time) int {
+	best := -1
+	for n := 0; n < len(p.endpoints); n++ {
+		i := (p.current + n) % len(p.endpoints)
+		e := p.endpoints[i]
+		if !e.available(now) {
+			continue
+		}
+		if best < 0 || scoreOf(e) < scoreOf(p.endpoints[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+func scoreOf(e *endpointState) float64 {
+	if !e.sampled {
+		return 0
+	}
+	return e.score()
+}
+
+// next moves the current endpoint forward in list order
+func (p *endpointPool) next() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current = (p.current + 1) % len(p.endpoints)
+}
+
+// rotate moves past endpoint after a failed attempt, so a retry goes to another
+// endpoint before the failure threshold quarantines it
+func (p *endpointPool) rotate(endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.endpoints[p.current].Endpoint == endpoint {
+		p.current = (p.current + 1) % len(p.endpoints)
+	}
+}
+
+func (p *endpointPool) find(endpoint string) *endpointState {
+	for _, e := range p.endpoints {
+		if e.Endpoint == endpoint {
+			return e
+		}
+	}
+	return nil
+}
+
+// report records the outcome of a request sent to endpoint
+func (p *endpointPool) report(endpoint string, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e := p.find(endpoint)
+	if e == nil {
+		return
+	}
+	e.Requests++
+	if err != nil {
+		e.Failures++
+		e.consecutiveFailures++
+		e.LastError = err.Error()
+		e.ErrorRate = ewma(e.ErrorRate, 1, e.sampled)
+		e.sampled = true
+		if p.cfg.FailureThreshold > 0 && e.consecutiveFailures >= p.cfg.FailureThreshold {
+			p.quarantine(e)
+		}
+		return
+	}
+	e.consecutiveFailures = 0
+	e.ErrorRate = ewma(e.ErrorRate, 0, e.sampled)
+	if e.Latency == 0 {
+		e.Latency = latency
+	} else {
+		e.Latency = time.Duration(ewma(float64(e.Latency), float64(latency), true))
+	}
+	e.sampled = true
+}
+
+// reportSlots records the slots seen by a probe and quarantines lagging endpoints
+func (p *endpointPool) reportSlots(slots map[string]uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	maxSlot := uint64(0)
+	for _, slot := range slots {
+		if slot > maxSlot {
+			maxSlot = slot
+		}
+	}
+	for endpoint, slot := range slots {
+		e := p.find(endpoint)
+		if e == nil {
+			continue
+		}
+		e.Slot = slot
+		e.SlotLag = maxSlot - slot
+		if p.cfg.MaxSlotLag > 0 && e.SlotLag > p.cfg.MaxSlotLag {
+			e.LastError = fmt.Sprintf("slot lag %d exceeds %d", e.SlotLag, p.cfg.MaxSlotLag)
+			p.quarantine(e)
+		}
+	}
+}
+
+func (p *endpointPool) quarantine(e *endpointState) {
+	e.QuarantinedUntil = time.Now().Add(p.cfg.QuarantineCooldown)
+}
+
+func (p *endpointPool) stats() []EndpointStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	stats := make([]EndpointStats, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		stat := e.EndpointStats
+		stat.Quarantined = !e.available(now)
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+func ewma(old, sample float64, sampled bool) float64 {
+	if !sampled {
+		return sample
+	}
+	return (1-ewmaAlpha)*old + ewmaAlpha*sample
+}
+
+// EndpointStats returns the health of every endpoint in the order given to NewClient
+func (s *Client) EndpointStats() []EndpointStats {
+	return s.pool.stats()
+}
+
+// ProbeEndpoints calls getHealth and getSlot on every endpoint, quarantining unhealthy
+// and lagging ones. Probes bypass the retry policy.
+func (s *Client) ProbeEndpoints(ctx context.Context) {
+	endpoints := make([]string, 0, len(s.pool.endpoints))
+	for _, e := range s.pool.endpoints {
+		endpoints = append(endpoints, e.Endpoint)
+	}
+
+	mu := sync.Mutex{}
+	slots := make(map[string]uint64)
+	wg := sync.WaitGroup{}
+	for _, endpoint := range endpoints {
+		wg.Add(1)
+		go func(endpoint string) {
+			defer wg.Done()
+			slot, latency, err := s.probe(ctx, endpoint)
+			if ctx.Err() != nil {
+				return
+			}
+			s.pool.report(endpoint, latency, err)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			slots[endpoint] = slot
+			mu.Unlock()
+		}(endpoint)
+	}
+	wg.Wait()
+	s.pool.reportSlots(slots)
+}
+
+func (s *Client) probe(ctx context.Context, endpoint string) (uint64, time.Duration, error) {
+	health, err := encodeRequest("getHealth", []interface{}{})
+	if err != nil {
+		return 0, 0, err
+	}
+	err = s.call(ctx, endpoint, health, &GeneralResponse{})
+	var rpcErr *RPCError
+	// getHealth is disabled by some providers, only a reported unhealthy node counts
+	if err != nil && (!errors.As(err, &rpcErr) || rpcErr.Code == RPCCodeNodeUnhealthy) {
+		return 0, 0, err
+	}
+
+	getSlot, err := encodeRequest("getSlot", []interface{}{GetSlotConfig{Commitment: CommitmentProcessed}})
+	if err != nil {
+		return 0, 0, err
+	}
+	res := struct {
+		GeneralResponse
+		Result uint64 `json:"result"`
+	}{}
+	begin := time.Now()
+	err = s.call(ctx, endpoint, getSlot, &res)
+	if err != nil {
+		return 0, 0, err
+	}
+	return res.Result, time.Since(begin), nil
+}
+
+// StartHealthCheck probes the endpoints every interval until ctx is done
+func (s *Client) StartHealthCheck(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.ProbeEndpoints(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,93 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func slotTransport(slots map[string]uint64) client.RPCTransport {
+	return client.RPCTransportFunc(func(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+		req := struct {
+			Method string `json:"method"`
+		}{}
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return nil, err
+		}
+		slot, ok := slots[endpoint]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		if req.Method == "getHealth" {
+			return []byte(rpcResult(`"ok"`)), nil
+		}
+		return []byte(rpcResult(strconv.FormatUint(slot, 10))), nil
+	})
+}
+
+func TestPoolQuarantinesLaggingEndpoint(t *testing.T) {
+	c := client.NewClient(
+		[]string{"lagging", "fresh"},
+		client.WithTransport(slotTransport(map[string]uint64{"lagging": 1000, "fresh": 1500})),
+	)
+	c.ProbeEndpoints(context.Background())
+
+	if c.Endpoint() != "fresh" {
+		t.Fatalf("endpoint = %s, want fresh", c.Endpoint())
+	}
+	stats := c.EndpointStats()
+	if !stats[0].Quarantined || stats[0].SlotLag != 500 {
+		t.Fatalf("lagging stats = %+v", stats[0])
+	}
+	if stats[1].Quarantined || stats[1].Slot != 1500 {
+		t.Fatalf("fresh stats = %+v", stats[1])
+	}
+}
+
+func TestPoolSkipsFailingEndpoint(t *testing.T) {
+	policy := client.RetryPolicy{MaxRetries: 1}
+	cfg := client.DefaultPoolConfig()
+	cfg.FailureThreshold = 1
+	c := client.NewClient(
+		[]string{"down", "up"},
+		client.WithTransport(slotTransport(map[string]uint64{"up": 10})),
+		client.WithRetryPolicy(policy),
+		client.WithPoolConfig(cfg),
+	)
+	for i := 0; i < 3; i++ {
+		slot, err := c.GetSlot(context.Background(), client.GetSlotConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slot != 10 {
+			t.Fatalf("slot = %d, want 10", slot)
+		}
+	}
+	stats := c.EndpointStats()
+	if stats[0].Requests != 1 || stats[0].Failures != 1 || !stats[0].Quarantined {
+		t.Fatalf("down stats = %+v", stats[0])
+	}
+	if stats[1].Requests != 3 || stats[1].Failures != 0 {
+		t.Fatalf("up stats = %+v", stats[1])
+	}
+}
+
+func TestPoolToleratesSingleFailure(t *testing.T) {
+	policy := client.RetryPolicy{MaxRetries: 1}
+	c := client.NewClient(
+		[]string{"down", "up"},
+		client.WithTransport(slotTransport(map[string]uint64{"up": 10})),
+		client.WithRetryPolicy(policy),
+	)
+	if _, err := c.GetSlot(context.Background(), client.GetSlotConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	stats := c.EndpointStats()
+	if stats[0].Failures != 1 || stats[0].Quarantined {
+		t.Fatalf("down stats = %+v, want one failure and no quarantine", stats[0])
+	}
+}
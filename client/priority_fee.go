@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+type EstimatePriorityFeeConfig struct {
+	Percentile float64 // of the recent slot fees, between 0 and 100, default: 75
+	Min        uint64  // the estimate is raised to Min micro-lamports
+	Max        uint64  // the estimate is capped to Max micro-lamports if not 0
+}
+
+// EstimatePriorityFee returns a compute unit price in micro-lamports for the message, taken at
+// cfg.Percentile of the recent prioritization fees paid to lock the message's writable accounts.
+// The result is meant for computebudgetprog.SetComputeUnitPrice
+func (s *Client) EstimatePriorityFee(ctx context.Context, message types.Message, cfg EstimatePriorityFeeConfig) (uint64, error) {
+	if cfg.Percentile == 0 {
+		cfg.Percentile = 75
+	}
+	if cfg.Percentile < 0 || cfg.Percentile > 100 {
+		return 0, fmt.Errorf("percentile %v out of range", cfg.Percentile)
+	}
+
+	addresses := writableAccounts(message)
+	if len(addresses) > MaxPrioritizationFeeAccounts {
+		addresses = addresses[:MaxPrioritizationFeeAccounts]
+	}
+	recentFees, err := s.GetRecentPrioritizationFees(ctx, addresses)
+	if err != nil {
+		return 0, err
+	}
+	fees := make([]uint64, 0, len(recentFees))
+	for _, fee := range recentFees {
+		fees = append(fees, fee.PrioritizationFee)
+	}
+
+	price := percentileFee(fees, cfg.Percentile)
+	if price < cfg.Min {
+		price = cfg.Min
+	}
+	if cfg.Max != 0 && price > cfg.Max {
+		price = cfg.Max
+	}
+	return price, nil
+}
+
+// writableAccounts returns the writable accounts of the message in base58
+func writableAccounts(message types.Message) []string {
+	addresses := []string{}
+	for i, account := range message.Accounts {
+		if message.IsWritable(i) {
+			addresses = append(addresses, account.ToBase58())
+		}
+	}
+	return addresses
+}
+
+// percentileFee picks the nearest-rank percentile of fees, 0 if there are none
+func percentileFee(fees []uint64, percentile float64) uint64 {
+	if len(fees) == 0 {
+		return 0
+	}
+	sorted := append([]uint64{}, fees...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(percentile / 100 * float64(len(sorted)))
+	if float64(rank) < percentile/100*float64(len(sorted)) {
+		rank++
+	}
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
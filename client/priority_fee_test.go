@@ -0,0 +1,59 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/sysprog"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+func TestEstimatePriorityFee(t *testing.T) {
+	from := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	message := types.NewMessage(from, []types.Instruction{sysprog.Transfer(from, to, 1)}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+
+	c, fake := newFakeClient(t, map[string]string{"getRecentPrioritizationFees": `[
+		{"slot":1,"prioritizationFee":0},{"slot":2,"prioritizationFee":100},
+		{"slot":3,"prioritizationFee":400},{"slot":4,"prioritizationFee":300},
+		{"slot":5,"prioritizationFee":200}]`})
+
+	tests := []struct {
+		name string
+		cfg  client.EstimatePriorityFeeConfig
+		want uint64
+	}{
+		{"default percentile", client.EstimatePriorityFeeConfig{}, 300},
+		{"median", client.EstimatePriorityFeeConfig{Percentile: 50}, 200},
+		{"max", client.EstimatePriorityFeeConfig{Percentile: 100}, 400},
+		{"floor", client.EstimatePriorityFeeConfig{Percentile: 1, Min: 50}, 50},
+		{"cap", client.EstimatePriorityFeeConfig{Percentile: 100, Max: 250}, 250},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.EstimatePriorityFee(context.Background(), message, tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("EstimatePriorityFee() = %d, want %d", got, tt.want)
+			}
+			// the system program is readonly and must not be sent
+			var gotAddresses []string
+			if err := json.Unmarshal(fake.lastParams()[0], &gotAddresses); err != nil {
+				t.Fatal(err)
+			}
+			if want := []string{from.ToBase58(), to.ToBase58()}; !reflect.DeepEqual(gotAddresses, want) {
+				t.Fatalf("addresses = %v, want %v", gotAddresses, want)
+			}
+		})
+	}
+
+	if _, err := c.EstimatePriorityFee(context.Background(), message, client.EstimatePriorityFeeConfig{Percentile: 101}); err == nil {
+		t.Fatal("expected an error for an out of range percentile")
+	}
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stafiprotocol/solana-go-sdk/lsdprog"
+	"github.com/stafiprotocol/solana-go-sdk/rsolprog"
+)
+
+// QuorumClient sends every read to all of its endpoints and only returns a value
+// that at least Quorum of them agree on at the highest common context slot
+type QuorumClient struct {
+	clients []*Client
+	quorum  int
+}
+
+// NewQuorumClient creates a client per endpoint with opts. A quorum <= 0 means a simple
+// majority of the endpoints.
+func NewQuorumClient(endpointList []string, quorum int, opts ...Option) *QuorumClient {
+	if len(endpointList) == 0 {
+		panic("endpoint empty")
+	}
+	if quorum <= 0 {
+		quorum = len(endpointList)/2 + 1
+	}
+	if quorum > len(endpointList) {
+		panic("quorum exceeds endpoint count")
+	}
+	clients := make([]*Client, 0, len(endpointList))
+	for _, endpoint := range endpointList {
+		clients = append(clients, NewClient([]string{endpoint}, opts...))
+	}
+	return &QuorumClient{clients: clients, quorum: quorum}
+}
+
+func (q *QuorumClient) Quorum() int {
+	return q.quorum
+}
+
+// QuorumResponse is what one endpoint answered
+type QuorumResponse struct {
+	Endpoint string
+	Slot     uint64
+	Value    []byte // json encoding of the value, nil if the account is not found
+	Err      error
+}
+
+// QuorumError is returned when no value reaches the quorum
+type QuorumError struct {
+	Quorum    int
+	Slot      uint64 // highest context slot reached by a quorum of endpoints, 0 if too few answered
+	Responses []QuorumResponse
+}
+
+func (e *QuorumError) Error() string {
+	parts := make([]string, 0, len(e.Responses))
+	for _, res := range e.Responses {
+		if res.Err != nil {
+			parts = append(parts, fmt.Sprintf("%s err: %s", res.Endpoint, res.Err))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s slot: %d", res.Endpoint, res.Slot))
+		}
+	}
+	return fmt.Sprintf("quorum %d not reached at slot %d, responses: [%s]", e.Quorum, e.Slot, strings.Join(parts, ", "))
+}
+
+// GetAccountInfo returns ErrAccountNotFound if a quorum agrees the account does not exist
+func (q *QuorumClient) GetAccountInfo(ctx context.Context, account string, cfg GetAccountInfoConfig) (GetAccountInfoResponse, error) {
+	_, value, err := q.getAccountInfo(ctx, account, cfg)
+	if err != nil {
+		return GetAccountInfoResponse{}, err
+	}
+	if value == nil {
+		return GetAccountInfoResponse{}, ErrAccountNotFound
+	}
+	return *value, nil
+}
+
+// GetAccountInfoAndContext returns the agreed account info with the slot a quorum reached,
+// the value is nil if a quorum agrees the account does not exist
+func (q *QuorumClient) GetAccountInfoAndContext(ctx context.Context, account string, cfg GetAccountInfoConfig) (ValueWithContext[*GetAccountInfoResponse], error) {
+	slot, value, err := q.getAccountInfo(ctx, account, cfg)
+	if err != nil {
+		return ValueWithContext[*GetAccountInfoResponse]{}, err
+	}
+	return ValueWithContext[*GetAccountInfoResponse]{Context: Context{Slot: slot}, Value: value}, nil
+}
+
+func (q *QuorumClient) GetLsdStakeManager(ctx context.Context, account string) (*lsdprog.StakeManager, error) {
+	accountInfo, err := q.GetAccountInfo(ctx, account, GetLsdStakeManagerCfgDefault)
+	if err != nil {
+		return nil, err
+	}
+	return decodeLsdStakeManager(accountInfo)
+}
+
+func (q *QuorumClient) GetStakeManager(ctx context.Context, account string) (*rsolprog.StakeManager, error) {
+	accountInfo, err := q.GetAccountInfo(ctx, account, GetStakeManagerCfgDefault)
+	if err != nil {
+		return nil, err
+	}
+	return decodeStakeManager(accountInfo)
+}
+
+func (q *QuorumClient) getAccountInfo(ctx context.Context, account string, cfg GetAccountInfoConfig) (uint64, *GetAccountInfoResponse, error) {
+	responses := make([]QuorumResponse, len(q.clients))
+	wg := sync.WaitGroup{}
+	for i, c := range q.clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			res := QuorumResponse{Endpoint: c.Endpoint()}
+			rpcCtx, value, err := c.getAccountInfo(ctx, account, cfg)
+			if err != nil {
+				res.Err = err
+			} else {
+				res.Slot = rpcCtx.Slot
+				if value != nil {
+					res.Value, res.Err = json.Marshal(value)
+				}
+			}
+			responses[i] = res
+		}(i, c)
+	}
+	wg.Wait()
+
+	slot, value, err := q.agree(responses)
+	if err != nil {
+		return 0, nil, err
+	}
+	if value == nil {
+		return slot, nil, nil
+	}
+	accountInfo := GetAccountInfoResponse{}
+	if err := json.Unmarshal(value, &accountInfo); err != nil {
+		return 0, nil, err
+	}
+	return slot, &accountInfo, nil
+}
+
+// agree drops responses older than the highest slot reached by a quorum of endpoints
+// and returns the value shared by at least a quorum of the rest
+func (q *QuorumClient) agree(responses []QuorumResponse) (uint64, []byte, error) {
+	slots := make([]uint64, 0, len(responses))
+	for _, res := range responses {
+		if res.Err == nil {
+			slots = append(slots, res.Slot)
+		}
+	}
+	if len(slots) < q.quorum {
+		return 0, nil, &QuorumError{Quorum: q.quorum, Responses: responses}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] > slots[j] })
+	commonSlot := slots[q.quorum-1]
+
+	for i, res := range responses {
+		if res.Err != nil || res.Slot < commonSlot {
+			continue
+		}
+		agreed := 0
+		for _, other := range responses[i:] {
+			if other.Err == nil && other.Slot >= commonSlot && bytes.Equal(other.Value, res.Value) {
+				agreed++
+			}
+		}
+		if agreed >= q.quorum {
+			return commonSlot, res.Value, nil
+		}
+	}
+	return 0, nil, &QuorumError{Quorum: q.quorum, Slot: commonSlot, Responses: responses}
+}
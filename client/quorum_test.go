@@ -0,0 +1,60 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+type accountResponse struct {
+	slot     uint64
+	lamports uint64
+}
+
+func accountTransport(responses map[string]accountResponse) client.RPCTransport {
+	return client.RPCTransportFunc(func(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+		res, ok := responses[endpoint]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return []byte(rpcResult(fmt.Sprintf(`{"context":{"slot":%d},"value":{"lamports":%d,"owner":"11111111111111111111111111111111","executable":false,"rentEpoch":0,"data":["","base64"]}}`, res.slot, res.lamports))), nil
+	})
+}
+
+func TestQuorumAgreement(t *testing.T) {
+	transport := accountTransport(map[string]accountResponse{
+		"a": {slot: 100, lamports: 5},
+		"b": {slot: 101, lamports: 5},
+		// stale view must not count
+		"c": {slot: 90, lamports: 4},
+	})
+	q := client.NewQuorumClient([]string{"a", "b", "c"}, 2, client.WithTransport(transport), client.WithRetryPolicy(client.RetryPolicy{}))
+
+	accountInfo, err := q.GetAccountInfo(context.Background(), "11111111111111111111111111111111", client.GetAccountInfoConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accountInfo.Lamports != 5 {
+		t.Fatalf("lamports = %d, want 5", accountInfo.Lamports)
+	}
+}
+
+func TestQuorumDisagreement(t *testing.T) {
+	transport := accountTransport(map[string]accountResponse{
+		"a": {slot: 100, lamports: 5},
+		"b": {slot: 100, lamports: 6},
+	})
+	q := client.NewQuorumClient([]string{"a", "b", "c"}, 2, client.WithTransport(transport), client.WithRetryPolicy(client.RetryPolicy{}))
+
+	_, err := q.GetAccountInfo(context.Background(), "11111111111111111111111111111111", client.GetAccountInfoConfig{})
+	var quorumErr *client.QuorumError
+	if !errors.As(err, &quorumErr) {
+		t.Fatalf("err = %v, want *QuorumError", err)
+	}
+	if quorumErr.Slot != 100 || len(quorumErr.Responses) != 3 {
+		t.Fatalf("quorum err = %+v", quorumErr)
+	}
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how a failed request is retried. Sleeps between
+// attempts use exponential backoff with jitter and return early when ctx is done.
+type RetryPolicy struct {
+	MaxRetries          int           // retries after the first attempt, 0 disables retry
+	InitialInterval     time.Duration // wait before the first retry
+	MaxInterval         time.Duration // upper bound of a single wait
+	Multiplier          float64       // growth factor of the wait, values below 1 are treated as 1
+	RandomizationFactor float64       // wait is randomized in [wait*(1-f), wait*(1+f)]
+	MaxElapsedTime      time.Duration // give up once this much time has passed, 0 means no limit
+	// Retryable decides whether err is worth another attempt, DefaultRetryable is used if nil
+	Retryable func(err error) bool
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:          retryLimit,
+		InitialInterval:     time.Second,
+		MaxInterval:         waitTime,
+		Multiplier:          2,
+		RandomizationFactor: 0.2,
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// DefaultRetryable retries transport and decode errors and rpc errors caused by the
+// node state, deterministic rpc errors like invalid params or a failed simulation are returned at once
+func DefaultRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.Code {
+		case RPCCodeBlockNotAvailable,
+			RPCCodeNodeUnhealthy,
+			RPCCodeBlockStatusNotAvailableYet,
+			RPCCodeMinContextSlotNotReached,
+			RPCCodeInternalError:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return DefaultRetryable(err)
+}
+
+// backoff returns the wait before retry number retry, counting from 0
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	wait := float64(p.InitialInterval)
+	for i := 0; i < retry; i++ {
+		wait *= multiplier
+		if p.MaxInterval > 0 && wait > float64(p.MaxInterval) {
+			break
+		}
+	}
+	if p.MaxInterval > 0 && wait > float64(p.MaxInterval) {
+		wait = float64(p.MaxInterval)
+	}
+	if p.RandomizationFactor > 0 {
+		delta := p.RandomizationFactor * wait
+		wait = wait - delta + rand.Float64()*2*delta
+	}
+	return time.Duration(wait)
+}
+
+// sleepCtx waits for d or until ctx is done
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+type recordTransport struct {
+	mu        sync.Mutex
+	endpoints []string
+	responses []string
+}
+
+func (r *recordTransport) Send(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints = append(r.endpoints, endpoint)
+	if len(r.responses) == 0 {
+		return nil, errors.New("connection refused")
+	}
+	res := r.responses[0]
+	r.responses = r.responses[1:]
+	return []byte(res), nil
+}
+
+func fastRetryPolicy() client.RetryPolicy {
+	return client.RetryPolicy{
+		MaxRetries:      3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+		Multiplier:      2,
+	}
+}
+
+func TestRetryDeterministicRPCError(t *testing.T) {
+	transport := &recordTransport{responses: []string{
+		rpcError(`{"code":-32602,"message":"Invalid params"}`),
+	}}
+	c := client.NewClient([]string{"a", "b"}, client.WithTransport(transport), client.WithRetryPolicy(fastRetryPolicy()))
+
+	_, err := c.GetBalance(context.Background(), "11111111111111111111111111111111")
+	var rpcErr *client.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32602 {
+		t.Fatalf("err = %v, want rpc error -32602", err)
+	}
+	if len(transport.endpoints) != 1 {
+		t.Fatalf("calls = %d, want 1", len(transport.endpoints))
+	}
+	if c.Endpoint() != "a" {
+		t.Fatalf("endpoint = %s, want a", c.Endpoint())
+	}
+	// the node answered, its health is not in question
+	if stats := c.EndpointStats(); stats[0].Failures != 0 {
+		t.Fatalf("stats = %+v, want no failure", stats[0])
+	}
+}
+
+func TestRetryRotatesEndpoint(t *testing.T) {
+	transport := &recordTransport{responses: []string{
+		rpcError(`{"code":-32005,"message":"Node is behind by 200 slots"}`),
+		rpcResult(`{"context":{"slot":1},"value":7}`),
+	}}
+	c := client.NewClient([]string{"a", "b"}, client.WithTransport(transport), client.WithRetryPolicy(fastRetryPolicy()))
+
+	balance, err := c.GetBalance(context.Background(), "11111111111111111111111111111111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 7 {
+		t.Fatalf("balance = %d, want 7", balance)
+	}
+	if len(transport.endpoints) != 2 || transport.endpoints[0] != "a" || transport.endpoints[1] != "b" {
+		t.Fatalf("endpoints = %v, want [a b]", transport.endpoints)
+	}
+}
+
+func TestRetryRespectsContext(t *testing.T) {
+	transport := &recordTransport{}
+	policy := fastRetryPolicy()
+	policy.InitialInterval = time.Hour
+	policy.MaxInterval = time.Hour
+	c := client.NewClient([]string{"a"}, client.WithTransport(transport), client.WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetBalance(ctx, "11111111111111111111111111111111")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("request blocked for %s after ctx done", time.Since(start))
+	}
+}
+
+func TestRetryCustomRetryable(t *testing.T) {
+	transport := &recordTransport{}
+	policy := fastRetryPolicy()
+	policy.Retryable = func(err error) bool { return false }
+	c := client.NewClient([]string{"a"}, client.WithTransport(transport), client.WithRetryPolicy(policy))
+
+	_, err := c.GetBalance(context.Background(), "11111111111111111111111111111111")
+	if err == nil {
+		t.Fatal("want err")
+	}
+	if len(transport.endpoints) != 1 {
+		t.Fatalf("calls = %d, want 1", len(transport.endpoints))
+	}
+	// not retried, but the refused connection still counts against the endpoint
+	if stats := c.EndpointStats(); stats[0].Failures != 1 {
+		t.Fatalf("stats = %+v, want one failure", stats[0])
+	}
+}
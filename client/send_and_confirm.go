@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBlockhashExpired is returned by SendAndConfirm once the block height passes the
+// last valid block height of the transaction blockhash, the transaction can no longer land
+var ErrBlockhashExpired = errors.New("blockhash expired")
+
+type SendAndConfirmConfig struct {
+	// Commitment to wait for, default: confirmed
+	Commitment Commitment
+	// LastValidBlockHeight of the blockhash the transaction was built with, as returned by
+	// GetLatestBlockhash, required
+	LastValidBlockHeight uint64
+	// RebroadcastInterval between sends of the same transaction, default: 2s
+	RebroadcastInterval time.Duration
+	// PollInterval between status checks, default: 1s
+	PollInterval time.Duration
+	// SkipPreflight of the first send, rebroadcasts always skip preflight
+	SkipPreflight       bool
+	PreflightCommitment Commitment
+}
+
+// SendAndConfirm sends tx and rebroadcasts it until it reaches the commitment. It returns the
+// signature with a *TransactionError if the transaction failed on chain, and ErrBlockhashExpired
+// if it can no longer be confirmed. Status and block height errors accepted by the retry policy
+// don't end the wait, only ctx and the expiry do.
+func (s *Client) SendAndConfirm(ctx context.Context, tx []byte, cfg SendAndConfirmConfig) (string, error) {
+	if cfg.LastValidBlockHeight == 0 {
+		return "", errors.New("send and confirm: LastValidBlockHeight is required")
+	}
+	if cfg.Commitment == "" {
+		cfg.Commitment = CommitmentConfirmed
+	}
+	if cfg.RebroadcastInterval <= 0 {
+		cfg.RebroadcastInterval = 2 * time.Second
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	preflightCommitment := cfg.PreflightCommitment
+	if preflightCommitment == "" {
+		preflightCommitment = cfg.Commitment
+	}
+
+	rawTx := base64.StdEncoding.EncodeToString(tx)
+	signature, err := s.SendTransaction(ctx, rawTx, SendTransactionConfig{
+		SkipPreflight:       cfg.SkipPreflight,
+		PreflightCommitment: preflightCommitment,
+		Encoding:            "base64",
+	})
+	if err != nil {
+		return "", err
+	}
+	lastBroadcast := time.Now()
+
+	// errors accepted by the retry policy leave the outcome unknown, polling goes on
+	terminal := func(err error) bool { return err != nil && !s.retryPolicy.retryable(err) }
+	for {
+		state, err := s.checkSignature(ctx, signature, cfg.Commitment)
+		if state == signatureDone || terminal(err) {
+			return signature, err
+		}
+
+		// a landed transaction no longer depends on its blockhash, it only waits for the commitment
+		if state == signatureUnknown {
+			height, err := s.GetBlockHeight(ctx, GetBlockHeightConfig{Commitment: CommitmentConfirmed})
+			if terminal(err) {
+				return signature, err
+			}
+			if err == nil && height > cfg.LastValidBlockHeight {
+				// the transaction may have landed right before the height check
+				state, err := s.checkSignature(ctx, signature, cfg.Commitment)
+				if state == signatureDone || terminal(err) {
+					return signature, err
+				}
+				if err == nil && state == signatureUnknown {
+					return signature, fmt.Errorf("%w, block height %d exceeds last valid block height %d", ErrBlockhashExpired, height, cfg.LastValidBlockHeight)
+				}
+			}
+		}
+
+		if time.Since(lastBroadcast) >= cfg.RebroadcastInterval {
+			// rebroadcast errors are ignored, the status check decides
+			s.SendTransaction(ctx, rawTx, SendTransactionConfig{
+				SkipPreflight:       true,
+				PreflightCommitment: preflightCommitment,
+				Encoding:            "base64",
+			})
+			lastBroadcast = time.Now()
+		}
+
+		if err := sleepCtx(ctx, cfg.PollInterval); err != nil {
+			return signature, err
+		}
+	}
+}
+
+// signatureState is the progress of a sent transaction seen by checkSignature
+type signatureState int
+
+const (
+	// signatureUnknown: the node has no status for the signature, the blockhash may still expire
+	signatureUnknown signatureState = iota
+	// signatureLanded: the transaction is in a block which has not reached the commitment yet
+	signatureLanded
+	// signatureDone: the commitment is reached or the transaction failed
+	signatureDone
+)
+
+// checkSignature reports how far signature got, with the transaction error if it failed
+func (s *Client) checkSignature(ctx context.Context, signature string, commitment Commitment) (signatureState, error) {
+	statuses, err := s.GetSignatureStatuses(ctx, []string{signature})
+	if err != nil {
+		return signatureUnknown, err
+	}
+	// status is null while the transaction is unknown to the node
+	if len(statuses) == 0 || statuses[0].Slot == 0 {
+		return signatureUnknown, nil
+	}
+	status := statuses[0]
+	if status.Err != nil {
+		txErr := TransactionError{}
+		raw, err := json.Marshal(status.Err)
+		if err == nil {
+			err = json.Unmarshal(raw, &txErr)
+		}
+		if err != nil {
+			return signatureDone, fmt.Errorf("transaction %s failed: %v", signature, status.Err)
+		}
+		return signatureDone, fmt.Errorf("transaction %s failed: %w", signature, &txErr)
+	}
+	if commitmentReached(status, commitment) {
+		return signatureDone, nil
+	}
+	return signatureLanded, nil
+}
+
+func commitmentReached(status GetSignatureStatusesResponse, commitment Commitment) bool {
+	// confirmations is null once the block is rooted
+	if status.Confirmations == nil {
+		return true
+	}
+	if status.ConfirmationStatus == nil {
+		return false
+	}
+	return commitmentLevel(*status.ConfirmationStatus) >= commitmentLevel(commitment)
+}
+
+func commitmentLevel(commitment Commitment) int {
+	switch commitment {
+	case CommitmentFinalized:
+		return 2
+	case CommitmentConfirmed:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,158 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+// chainTransport answers sendTransaction, getSignatureStatuses and getBlockHeight, the
+// status of the transaction is given by status after the nth status request, "" fails the request
+type chainTransport struct {
+	mu          sync.Mutex
+	sends       int
+	statusCalls int
+	status      func(n int) string
+	blockHeight uint64
+}
+
+func (c *chainTransport) Send(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+	req := struct {
+		Method string `json:"method"`
+	}{}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch req.Method {
+	case "sendTransaction":
+		c.sends++
+		return []byte(rpcResult(`"sig"`)), nil
+	case "getSignatureStatuses":
+		c.statusCalls++
+		if c.status(c.statusCalls) == "" {
+			return nil, errors.New("connection reset")
+		}
+		return []byte(rpcResult(`{"context":{"slot":1},"value":[` + c.status(c.statusCalls) + `]}`)), nil
+	case "getBlockHeight":
+		c.blockHeight += 10
+		return []byte(rpcResult(strconv.FormatUint(c.blockHeight, 10))), nil
+	}
+	return nil, fmt.Errorf("unexpected method %s", req.Method)
+}
+
+var fastConfirm = client.SendAndConfirmConfig{
+	LastValidBlockHeight: 1000,
+	RebroadcastInterval:  time.Millisecond,
+	PollInterval:         time.Millisecond,
+}
+
+func TestSendAndConfirm(t *testing.T) {
+	transport := &chainTransport{status: func(n int) string {
+		switch {
+		case n < 3:
+			return "null"
+		case n < 5:
+			return `{"slot":9,"confirmations":0,"confirmationStatus":"processed","err":null}`
+		default:
+			return `{"slot":9,"confirmations":1,"confirmationStatus":"confirmed","err":null}`
+		}
+	}}
+	c := client.NewClient([]string{"fake"}, client.WithTransport(transport))
+
+	sig, err := c.SendAndConfirm(context.Background(), []byte{1}, fastConfirm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig != "sig" {
+		t.Fatalf("sig = %s", sig)
+	}
+	if transport.statusCalls != 5 {
+		t.Fatalf("status calls = %d, want 5", transport.statusCalls)
+	}
+	if transport.sends < 2 {
+		t.Fatalf("sends = %d, want rebroadcast", transport.sends)
+	}
+}
+
+func TestSendAndConfirmExpired(t *testing.T) {
+	transport := &chainTransport{status: func(n int) string { return "null" }}
+	c := client.NewClient([]string{"fake"}, client.WithTransport(transport))
+
+	cfg := fastConfirm
+	cfg.LastValidBlockHeight = 35
+	_, err := c.SendAndConfirm(context.Background(), []byte{1}, cfg)
+	if !errors.Is(err, client.ErrBlockhashExpired) {
+		t.Fatalf("err = %v, want blockhash expired", err)
+	}
+}
+
+func TestSendAndConfirmFailed(t *testing.T) {
+	transport := &chainTransport{status: func(n int) string {
+		return `{"slot":9,"confirmations":1,"confirmationStatus":"confirmed","err":{"InstructionError":[0,{"Custom":2}]}}`
+	}}
+	c := client.NewClient([]string{"fake"}, client.WithTransport(transport))
+
+	_, err := c.SendAndConfirm(context.Background(), []byte{1}, fastConfirm)
+	var txErr *client.TransactionError
+	if !errors.As(err, &txErr) || *txErr.InstructionError.Custom != 2 {
+		t.Fatalf("err = %v, want instruction error", err)
+	}
+}
+
+func TestSendAndConfirmTransientErr(t *testing.T) {
+	transport := &chainTransport{status: func(n int) string {
+		if n < 3 {
+			return ""
+		}
+		return `{"slot":9,"confirmations":1,"confirmationStatus":"confirmed","err":null}`
+	}}
+	c := client.NewClient([]string{"fake"}, client.WithTransport(transport), client.WithRetryPolicy(client.RetryPolicy{}))
+
+	if _, err := c.SendAndConfirm(context.Background(), []byte{1}, fastConfirm); err != nil {
+		t.Fatal(err)
+	}
+	if transport.statusCalls != 3 {
+		t.Fatalf("status calls = %d, want 3", transport.statusCalls)
+	}
+
+	cfg := fastConfirm
+	cfg.LastValidBlockHeight = 0
+	if _, err := c.SendAndConfirm(context.Background(), []byte{1}, cfg); err == nil {
+		t.Fatal("want err without last valid block height")
+	}
+}
+
+// a landed transaction waits for the commitment after the blockhash expires, re-signing it
+// would risk a double spend
+func TestSendAndConfirmLandedNotExpired(t *testing.T) {
+	// the height passes the limit on the second poll, the recheck sees the transaction landed
+	transport := &chainTransport{status: func(n int) string {
+		if n < 3 {
+			return "null"
+		}
+		if n < 8 {
+			return `{"slot":9,"confirmations":1,"confirmationStatus":"confirmed","err":null}`
+		}
+		return `{"slot":9,"confirmations":null,"confirmationStatus":"finalized","err":null}`
+	}}
+	c := client.NewClient([]string{"fake"}, client.WithTransport(transport))
+
+	cfg := fastConfirm
+	cfg.Commitment = client.CommitmentFinalized
+	cfg.LastValidBlockHeight = 15
+	if _, err := c.SendAndConfirm(context.Background(), []byte{1}, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if transport.statusCalls != 8 {
+		t.Fatalf("status calls = %d, want 8", transport.statusCalls)
+	}
+}
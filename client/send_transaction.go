@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"encoding/base64"
-	"errors"
 )
 
 type SendTransactionConfig struct {
@@ -34,9 +33,6 @@ func (s *Client) SendRawTransaction(ctx context.Context, tx []byte) (string, err
 	if err != nil {
 		return "", err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return "", errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
 
@@ -49,8 +45,5 @@ func (s *Client) SendTransaction(ctx context.Context, tx string, cfg SendTransac
 	if err != nil {
 		return "", err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return "", errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
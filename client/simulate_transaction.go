@@ -1,14 +1,13 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type SimulateTransactionConfig struct {
-	SigVerify           bool       `json:"sigVerify"`           // default: false
-	PreflightCommitment Commitment `json:"preflightCommitment"` // default: max
-	Encoding            string     `json:"encoding"`            // base58 or base64
+	SigVerify           bool       `json:"sigVerify"`                     // default: false
+	PreflightCommitment Commitment `json:"preflightCommitment,omitempty"` // ignored by simulateTransaction, use Commitment
+	Commitment          Commitment `json:"commitment,omitempty"`          // default: finalized
+	MinContextSlot      uint64     `json:"minContextSlot,omitempty"`
+	Encoding            string     `json:"encoding"` // base58 or base64
 }
 
 type SimulateTransactionResponse struct {
@@ -17,19 +16,22 @@ type SimulateTransactionResponse struct {
 }
 
 func (s *Client) SimulateTransaction(ctx context.Context, rawTx string, cfg SimulateTransactionConfig) (SimulateTransactionResponse, error) {
+	res, err := s.SimulateTransactionAndContext(ctx, rawTx, cfg)
+	if err != nil {
+		return SimulateTransactionResponse{}, err
+	}
+	return res.Value, nil
+}
+
+// SimulateTransactionAndContext returns the simulation result with the slot it was run at
+func (s *Client) SimulateTransactionAndContext(ctx context.Context, rawTx string, cfg SimulateTransactionConfig) (ValueWithContext[SimulateTransactionResponse], error) {
 	res := struct {
 		GeneralResponse
-		Result struct {
-			Context Context                     `json:"context"`
-			Value   SimulateTransactionResponse `json:"value"`
-		} `json:"result"`
+		Result ValueWithContext[SimulateTransactionResponse] `json:"result"`
 	}{}
 	err := s.request(ctx, "simulateTransaction", []interface{}{rawTx, cfg}, &res)
 	if err != nil {
-		return SimulateTransactionResponse{}, err
-	}
-	if res.Error != (ErrorResponse{}) {
-		return SimulateTransactionResponse{}, errors.New(res.Error.Message)
+		return ValueWithContext[SimulateTransactionResponse]{}, err
 	}
-	return res.Result.Value, nil
+	return res.Result, nil
 }
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RPCTransport delivers an encoded json rpc payload to endpoint and returns the raw response body.
+// Implementations must be safe for concurrent use.
+type RPCTransport interface {
+	Send(ctx context.Context, endpoint string, payload []byte) ([]byte, error)
+}
+
+// RPCTransportFunc adapts an ordinary function to RPCTransport
+type RPCTransportFunc func(ctx context.Context, endpoint string, payload []byte) ([]byte, error)
+
+func (f RPCTransportFunc) Send(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+	return f(ctx, endpoint, payload)
+}
+
+// HTTPStatusError is returned by HTTPTransport when the endpoint answers with a non 200 status code
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("status code: %d", e.StatusCode)
+}
+
+// HTTPTransport posts json rpc payloads over http
+type HTTPTransport struct {
+	Client *http.Client
+	// Header is added to every request, e.g. an Authorization header required by the rpc provider
+	Header http.Header
+}
+
+// NewHTTPTransport returns a transport using httpClient, http.DefaultClient is used if httpClient is nil
+func NewHTTPTransport(httpClient *http.Client) *HTTPTransport {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPTransport{
+		Client: httpClient,
+		Header: make(http.Header),
+	}
+}
+
+func (t *HTTPTransport) Send(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	for key, values := range t.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	httpClient := t.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// check status code
+	if res.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{StatusCode: res.StatusCode}
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("body empty")
+	}
+	return body, nil
+}
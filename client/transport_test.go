@@ -0,0 +1,136 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func TestTransportFake(t *testing.T) {
+	var caller client.RPCCaller
+	caller, fake := newFakeClient(t, map[string]string{"getBalance": `{"context":{"slot":1},"value":42}`})
+	balance, err := caller.GetBalance(context.Background(), "11111111111111111111111111111111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.callCount("getBalance") != 1 {
+		t.Fatalf("getBalance calls = %d, want 1", fake.callCount("getBalance"))
+	}
+	if balance != 42 {
+		t.Fatalf("balance = %d, want 42", balance)
+	}
+}
+
+func TestHTTPTransportHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(rpcResult(`{"solana-core":"1.18.0","feature-set":1}`)))
+	}))
+	defer server.Close()
+
+	transport := client.NewHTTPTransport(server.Client())
+	transport.Header.Set("Authorization", "Bearer token")
+	c := client.NewClient([]string{server.URL}, client.WithTransport(transport))
+
+	version, err := c.GetVersion(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.SolanaCore != "1.18.0" {
+		t.Fatalf("solana core = %s, want 1.18.0", version.SolanaCore)
+	}
+}
+
+// fakeRPC serves canned JSON RPC responses keyed by method and records the params of
+// the last request
+type fakeRPC struct {
+	t        *testing.T
+	mu       sync.Mutex
+	handlers map[string]func(params []json.RawMessage) string
+	params   []json.RawMessage
+	calls    map[string]int
+}
+
+// newFakeClient returns a client whose transport answers each method with the raw JSON
+// result registered for it in results, other responses can be added with handle
+func newFakeClient(t *testing.T, results map[string]string, opts ...client.Option) (*client.Client, *fakeRPC) {
+	fake := &fakeRPC{
+		t:        t,
+		handlers: make(map[string]func(params []json.RawMessage) string),
+		calls:    make(map[string]int),
+	}
+	for method, result := range results {
+		fake.result(method, result)
+	}
+	opts = append([]client.Option{client.WithTransport(client.RPCTransportFunc(fake.send))}, opts...)
+	return client.NewClient([]string{"fake"}, opts...), fake
+}
+
+// handle answers method with the response body built by respond
+func (f *fakeRPC) handle(method string, respond func(params []json.RawMessage) string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[method] = respond
+}
+
+// result answers method with the raw JSON result
+func (f *fakeRPC) result(method, result string) {
+	f.handle(method, func([]json.RawMessage) string { return rpcResult(result) })
+}
+
+// fail answers method with the raw JSON error object
+func (f *fakeRPC) fail(method, err string) {
+	f.handle(method, func([]json.RawMessage) string { return rpcError(err) })
+}
+
+// lastParams returns the params of the last request
+func (f *fakeRPC) lastParams() []json.RawMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.params
+}
+
+// callCount returns the number of requests sent for method
+func (f *fakeRPC) callCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func (f *fakeRPC) send(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+	req := struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}{}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	f.params = req.Params
+	f.calls[req.Method]++
+	respond, ok := f.handlers[req.Method]
+	f.mu.Unlock()
+	if !ok {
+		f.t.Errorf("unexpected method %s", req.Method)
+		return []byte(rpcError(`{"code":-32601,"message":"Method not found"}`)), nil
+	}
+	return []byte(respond(req.Params)), nil
+}
+
+// rpcResult wraps a raw JSON result in a response envelope
+func rpcResult(result string) string {
+	return `{"jsonrpc":"2.0","id":0,"result":` + result + `}`
+}
+
+// rpcError wraps a raw JSON error object in a response envelope
+func rpcError(err string) string {
+	return `{"jsonrpc":"2.0","id":0,"error":` + err + `}`
+}
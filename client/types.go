@@ -1,5 +1,31 @@
 package client
 
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// TransactionVersionLegacy is the version of transactions without a version prefix
+const TransactionVersionLegacy = TransactionVersion("legacy")
+
+// TransactionVersion is "legacy" or the number of a versioned transaction, it is
+// empty if the node did not report the version
+type TransactionVersion string
+
+func (v *TransactionVersion) UnmarshalJSON(data []byte) error {
+	var number uint8
+	if err := json.Unmarshal(data, &number); err == nil {
+		*v = TransactionVersion(strconv.Itoa(int(number)))
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*v = TransactionVersion(s)
+	return nil
+}
+
 type Instruction struct {
 	ProgramIDIndex uint64   `json:"programIdIndex"`
 	Accounts       []uint64 `json:"accounts"`
@@ -17,8 +43,15 @@ type TransactionMeta struct {
 		Index        uint64        `json:"index"`
 		Instructions []Instruction `json:"instructions"`
 	} `json:"innerInstructions"`
-	Err    interface{}            `json:"err"`
-	Status map[string]interface{} `json:"status"`
+	Err             interface{}            `json:"err"`
+	Status          map[string]interface{} `json:"status"`
+	LoadedAddresses LoadedAddresses        `json:"loadedAddresses"`
+}
+
+// LoadedAddresses are the accounts a v0 transaction loaded from address lookup tables
+type LoadedAddresses struct {
+	Writable []string `json:"writable"`
+	Readonly []string `json:"readonly"`
 }
 
 type MessageHeader struct {
@@ -28,10 +61,17 @@ type MessageHeader struct {
 }
 
 type Message struct {
-	Header          MessageHeader `json:"header"`
-	AccountKeys     []string      `json:"accountKeys"`
-	RecentBlockhash string        `json:"recentBlockhash"`
-	Instructions    []Instruction `json:"instructions"`
+	Header              MessageHeader        `json:"header"`
+	AccountKeys         []string             `json:"accountKeys"`
+	RecentBlockhash     string               `json:"recentBlockhash"`
+	Instructions        []Instruction        `json:"instructions"`
+	AddressTableLookups []AddressTableLookup `json:"addressTableLookups,omitempty"`
+}
+
+type AddressTableLookup struct {
+	AccountKey      string   `json:"accountKey"`
+	WritableIndexes []uint64 `json:"writableIndexes"`
+	ReadonlyIndexes []uint64 `json:"readonlyIndexes"`
 }
 
 type Transaction struct {
@@ -39,6 +79,16 @@ type Transaction struct {
 	Message    Message  `json:"message"`
 }
 
+// AllAccountKeys returns the full account list instruction indexes refer to, the static
+// account keys followed by the writable and readonly addresses loaded from lookup tables
+func AllAccountKeys(tx Transaction, meta TransactionMeta) []string {
+	keys := make([]string, 0, len(tx.Message.AccountKeys)+len(meta.LoadedAddresses.Writable)+len(meta.LoadedAddresses.Readonly))
+	keys = append(keys, tx.Message.AccountKeys...)
+	keys = append(keys, meta.LoadedAddresses.Writable...)
+	keys = append(keys, meta.LoadedAddresses.Readonly...)
+	return keys
+}
+
 type TokenBalance struct {
 	AccountIndex  uint64 `json:"accountIndex"`
 	Mint          string `json:"mint"`
@@ -0,0 +1,50 @@
+package client_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+func TestGetTransactionResponseV0(t *testing.T) {
+	raw := `{
+		"slot": 10,
+		"version": 0,
+		"meta": {"fee": 5000, "err": null, "loadedAddresses": {"writable": ["W1"], "readonly": ["R1", "R2"]}},
+		"transaction": {
+			"signatures": ["sig"],
+			"message": {
+				"header": {"numRequiredSignatures": 1, "numReadonlySignedAccounts": 0, "numReadonlyUnsignedAccounts": 1},
+				"accountKeys": ["payer", "program"],
+				"recentBlockhash": "hash",
+				"instructions": [{"programIdIndex": 1, "accounts": [0, 2, 3, 4], "data": ""}],
+				"addressTableLookups": [{"accountKey": "table", "writableIndexes": [3], "readonlyIndexes": [0, 1]}]
+			}
+		}
+	}`
+	var res client.GetTransactionResponse
+	if err := json.Unmarshal([]byte(raw), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Version != "0" {
+		t.Fatalf("version = %q, want 0", res.Version)
+	}
+	lookups := res.Transaction.Message.AddressTableLookups
+	if len(lookups) != 1 || lookups[0].AccountKey != "table" || !reflect.DeepEqual(lookups[0].ReadonlyIndexes, []uint64{0, 1}) {
+		t.Fatalf("lookups = %+v", lookups)
+	}
+	want := []string{"payer", "program", "W1", "R1", "R2"}
+	if got := res.AccountKeys(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("AccountKeys() = %v, want %v", got, want)
+	}
+
+	var legacy client.GetTransactionResponse
+	if err := json.Unmarshal([]byte(`{"slot": 1, "version": "legacy"}`), &legacy); err != nil {
+		t.Fatal(err)
+	}
+	if legacy.Version != client.TransactionVersionLegacy {
+		t.Fatalf("version = %q, want legacy", legacy.Version)
+	}
+}
@@ -0,0 +1,91 @@
+package computebudgetprog
+
+import (
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+type Instruction uint8
+
+const (
+	InstructionRequestUnitsDeprecated Instruction = iota
+	InstructionRequestHeapFrame
+	InstructionSetComputeUnitLimit
+	InstructionSetComputeUnitPrice
+	InstructionSetLoadedAccountsDataSizeLimit
+)
+
+const (
+	// MaxComputeUnitLimit is the most compute units a transaction can request
+	MaxComputeUnitLimit = 1_400_000
+	// MaxHeapFrameBytes is the largest heap frame a transaction can request, it must be a multiple of 1024
+	MaxHeapFrameBytes = 256 * 1024
+)
+
+// RequestHeapFrame requests a heap frame of bytes for every program of the transaction
+func RequestHeapFrame(bytes uint32) types.Instruction {
+	data, err := common.SerializeData(struct {
+		Instruction Instruction
+		Bytes       uint32
+	}{
+		Instruction: InstructionRequestHeapFrame,
+		Bytes:       bytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return newInstruction(data)
+}
+
+// SetComputeUnitLimit sets the compute units the transaction may consume
+func SetComputeUnitLimit(units uint32) types.Instruction {
+	data, err := common.SerializeData(struct {
+		Instruction Instruction
+		Units       uint32
+	}{
+		Instruction: InstructionSetComputeUnitLimit,
+		Units:       units,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return newInstruction(data)
+}
+
+// SetComputeUnitPrice sets the priority fee paid per compute unit, in micro-lamports
+func SetComputeUnitPrice(microLamports uint64) types.Instruction {
+	data, err := common.SerializeData(struct {
+		Instruction   Instruction
+		MicroLamports uint64
+	}{
+		Instruction:   InstructionSetComputeUnitPrice,
+		MicroLamports: microLamports,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return newInstruction(data)
+}
+
+// SetLoadedAccountsDataSizeLimit sets the total account data bytes the transaction may load
+func SetLoadedAccountsDataSizeLimit(bytes uint32) types.Instruction {
+	data, err := common.SerializeData(struct {
+		Instruction Instruction
+		Bytes       uint32
+	}{
+		Instruction: InstructionSetLoadedAccountsDataSizeLimit,
+		Bytes:       bytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return newInstruction(data)
+}
+
+func newInstruction(data []byte) types.Instruction {
+	return types.Instruction{
+		Accounts:  []types.AccountMeta{},
+		ProgramID: common.ComputeBudgetProgramID,
+		Data:      data,
+	}
+}
@@ -0,0 +1,35 @@
+package computebudgetprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+func TestInstructions(t *testing.T) {
+	tests := []struct {
+		name        string
+		instruction types.Instruction
+		want        []byte
+	}{
+		{"RequestHeapFrame", RequestHeapFrame(MaxHeapFrameBytes), []byte{1, 0, 0, 4, 0}},
+		{"SetComputeUnitLimit", SetComputeUnitLimit(200_000), []byte{2, 64, 13, 3, 0}},
+		{"SetComputeUnitPrice", SetComputeUnitPrice(1_000), []byte{3, 232, 3, 0, 0, 0, 0, 0, 0}},
+		{"SetLoadedAccountsDataSizeLimit", SetLoadedAccountsDataSizeLimit(65536), []byte{4, 0, 0, 1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.instruction.ProgramID != common.ComputeBudgetProgramID {
+				t.Errorf("program id = %s", tt.instruction.ProgramID.ToBase58())
+			}
+			if len(tt.instruction.Accounts) != 0 {
+				t.Errorf("accounts = %v, want none", tt.instruction.Accounts)
+			}
+			if !reflect.DeepEqual(tt.instruction.Data, tt.want) {
+				t.Errorf("data = %v, want %v", tt.instruction.Data, tt.want)
+			}
+		})
+	}
+}
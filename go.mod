@@ -4,11 +4,15 @@ go 1.21
 
 require (
 	github.com/dfuse-io/logging v0.0.0-20201110202154-26697de88c79
+	github.com/gorilla/websocket v1.5.3
+	github.com/klauspost/compress v1.17.9
 	github.com/mr-tron/base58 v1.2.0
 	github.com/near/borsh-go v0.3.2-0.20220516180422-1ff87d108454
 	github.com/teserakt-io/golang-ed25519 v0.0.0-20210104091850-3888c087a4c8
 	github.com/tidwall/gjson v1.6.3
+	github.com/tyler-smith/go-bip39 v1.1.0
 	go.uber.org/zap v1.16.0
+	golang.org/x/crypto v0.33.0
 	golang.org/x/sync v0.7.0
 )
 
@@ -23,4 +27,5 @@ require (
 	go.opencensus.io v0.22.1 // indirect
 	go.uber.org/atomic v1.6.0 // indirect
 	go.uber.org/multierr v1.5.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
 )
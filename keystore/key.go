@@ -0,0 +1,262 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/stafiprotocol/solana-go-sdk/types"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Version is the version of the key file format
+const Version = 1
+
+const (
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+
+	CipherAESGCM            = "aes-256-gcm"
+	CipherXChaCha20Poly1305 = "xchacha20-poly1305"
+)
+
+const (
+	keyLen  = 32
+	saltLen = 32
+)
+
+// Upper bounds of the kdf params accepted from a key file, a crafted file must not be able
+// to make an unlock allocate gigabytes or run for hours
+const (
+	maxScryptN      = 1 << 20
+	maxScryptRP     = 256
+	maxScryptMemory = 1 << 30 // 128 * N * R bytes
+	maxArgon2Memory = 1 << 20 // in KiB, 1 GiB
+	maxArgon2Time   = 16
+)
+
+// ErrDecrypt is returned when the password is wrong or the key file was tampered with
+var ErrDecrypt = errors.New("could not decrypt key with given password")
+
+// KeyFile is the JSON document holding one encrypted account, it is modelled on the
+// Ethereum keystore v3 with an AEAD cipher in place of the separate mac
+type KeyFile struct {
+	Version   int        `json:"version"`
+	PublicKey string     `json:"publicKey"`
+	Crypto    CryptoJSON `json:"crypto"`
+}
+
+type CryptoJSON struct {
+	Cipher       string       `json:"cipher"`
+	CipherText   string       `json:"ciphertext"`
+	CipherParams CipherParams `json:"cipherparams"`
+	KDF          string       `json:"kdf"`
+	KDFParams    KDFParams    `json:"kdfparams"`
+}
+
+type CipherParams struct {
+	Nonce string `json:"nonce"`
+}
+
+// KDFParams holds the parameters of either kdf, N, R and P are used by scrypt,
+// Time, Memory and Threads by argon2id
+type KDFParams struct {
+	Salt    string `json:"salt"`
+	N       int    `json:"n,omitempty"`
+	R       int    `json:"r,omitempty"`
+	P       int    `json:"p,omitempty"`
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"` // in KiB
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// Params selects the kdf, its cost and the cipher used to encrypt new keys
+type Params struct {
+	KDF       string
+	KDFParams KDFParams // Salt is ignored, a random one is drawn for every key
+	Cipher    string
+}
+
+// StandardScryptParams matches the cost of the Ethereum keystore, about a second per unlock
+var StandardScryptParams = Params{
+	KDF:       KDFScrypt,
+	KDFParams: KDFParams{N: 1 << 18, R: 8, P: 1},
+	Cipher:    CipherAESGCM,
+}
+
+// LightScryptParams is cheap enough for tests and machines with little memory
+var LightScryptParams = Params{
+	KDF:       KDFScrypt,
+	KDFParams: KDFParams{N: 1 << 12, R: 8, P: 6},
+	Cipher:    CipherAESGCM,
+}
+
+// StandardArgon2idParams follows the RFC 9106 recommendation for memory constrained environments
+var StandardArgon2idParams = Params{
+	KDF:       KDFArgon2id,
+	KDFParams: KDFParams{Time: 3, Memory: 64 * 1024, Threads: 4},
+	Cipher:    CipherXChaCha20Poly1305,
+}
+
+// EncryptKey encrypts the account's seed with a key derived from password
+func EncryptKey(account types.Account, password string, params Params) ([]byte, error) {
+	if len(account.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("private key length %d err", len(account.PrivateKey))
+	}
+	kdfParams := params.KDFParams
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	kdfParams.Salt = hex.EncodeToString(salt)
+
+	derivedKey, err := deriveKey(params.KDF, kdfParams, password)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(params.Cipher, derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	publicKey := account.PublicKey.ToBase58()
+	cipherText := aead.Seal(nil, nonce, account.PrivateKey.Seed(), []byte(publicKey))
+
+	return json.Marshal(KeyFile{
+		Version:   Version,
+		PublicKey: publicKey,
+		Crypto: CryptoJSON{
+			Cipher:       params.Cipher,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: CipherParams{Nonce: hex.EncodeToString(nonce)},
+			KDF:          params.KDF,
+			KDFParams:    kdfParams,
+		},
+	})
+}
+
+// DecryptKey decrypts a key file, the public key is authenticated along with the seed
+func DecryptKey(data []byte, password string) (types.Account, error) {
+	keyFile := KeyFile{}
+	if err := json.Unmarshal(data, &keyFile); err != nil {
+		return types.Account{}, fmt.Errorf("key file decode err: %s", err)
+	}
+	if keyFile.Version != Version {
+		return types.Account{}, fmt.Errorf("key file version %d not supported", keyFile.Version)
+	}
+	nonce, err := hex.DecodeString(keyFile.Crypto.CipherParams.Nonce)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("nonce decode err: %s", err)
+	}
+	cipherText, err := hex.DecodeString(keyFile.Crypto.CipherText)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("ciphertext decode err: %s", err)
+	}
+
+	derivedKey, err := deriveKey(keyFile.Crypto.KDF, keyFile.Crypto.KDFParams, password)
+	if err != nil {
+		return types.Account{}, err
+	}
+	aead, err := newAEAD(keyFile.Crypto.Cipher, derivedKey)
+	if err != nil {
+		return types.Account{}, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return types.Account{}, fmt.Errorf("nonce length %d err", len(nonce))
+	}
+	seed, err := aead.Open(nil, nonce, cipherText, []byte(keyFile.PublicKey))
+	if err != nil {
+		return types.Account{}, ErrDecrypt
+	}
+	if len(seed) != ed25519.SeedSize {
+		return types.Account{}, fmt.Errorf("seed length %d err", len(seed))
+	}
+
+	account := types.AccountFromPrivateKeyBytes(ed25519.NewKeyFromSeed(seed))
+	if account.PublicKey.ToBase58() != keyFile.PublicKey {
+		return types.Account{}, fmt.Errorf("key file public key %s does not match the secret", keyFile.PublicKey)
+	}
+	return account, nil
+}
+
+// ChangePassword re-encrypts the key file with newPassword, keeping its kdf and cipher
+func ChangePassword(data []byte, oldPassword, newPassword string) ([]byte, error) {
+	account, err := DecryptKey(data, oldPassword)
+	if err != nil {
+		return nil, err
+	}
+	keyFile := KeyFile{}
+	if err := json.Unmarshal(data, &keyFile); err != nil {
+		return nil, err
+	}
+	return EncryptKey(account, newPassword, Params{
+		KDF:       keyFile.Crypto.KDF,
+		KDFParams: keyFile.Crypto.KDFParams,
+		Cipher:    keyFile.Crypto.Cipher,
+	})
+}
+
+func deriveKey(kdf string, params KDFParams, password string) ([]byte, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("salt decode err: %s", err)
+	}
+	if err := checkKDFParams(kdf, params); err != nil {
+		return nil, err
+	}
+	switch kdf {
+	case KDFScrypt:
+		return scrypt.Key([]byte(password), salt, params.N, params.R, params.P, keyLen)
+	default:
+		return argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, keyLen), nil
+	}
+}
+
+// checkKDFParams rejects params which are invalid or too costly, before anything is derived
+func checkKDFParams(kdf string, params KDFParams) error {
+	switch kdf {
+	case KDFScrypt:
+		if params.N <= 1 || params.N&(params.N-1) != 0 || params.R <= 0 || params.P <= 0 {
+			return fmt.Errorf("scrypt params n %d, r %d, p %d err", params.N, params.R, params.P)
+		}
+		if params.N > maxScryptN || params.R > maxScryptRP/params.P || 128*params.N > maxScryptMemory/params.R {
+			return fmt.Errorf("scrypt params n %d, r %d, p %d exceed the limits", params.N, params.R, params.P)
+		}
+	case KDFArgon2id:
+		if params.Time == 0 || params.Memory == 0 || params.Threads == 0 {
+			return errors.New("argon2id params must not be zero")
+		}
+		// Threads is a uint8, capped at 255 by its type
+		if params.Time > maxArgon2Time || params.Memory > maxArgon2Memory {
+			return fmt.Errorf("argon2id params time %d, memory %d KiB exceed the limits", params.Time, params.Memory)
+		}
+	default:
+		return fmt.Errorf("kdf %s not supported", kdf)
+	}
+	return nil
+}
+
+func newAEAD(name string, key []byte) (cipher.AEAD, error) {
+	switch name {
+	case CipherAESGCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case CipherXChaCha20Poly1305:
+		return chacha20poly1305.NewX(key)
+	default:
+		return nil, fmt.Errorf("cipher %s not supported", name)
+	}
+}
@@ -0,0 +1,164 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+var testArgon2idParams = Params{
+	KDF:       KDFArgon2id,
+	KDFParams: KDFParams{Time: 1, Memory: 1024, Threads: 1},
+	Cipher:    CipherXChaCha20Poly1305,
+}
+
+func TestEncryptDecryptKey(t *testing.T) {
+	account := types.NewAccount()
+	for _, params := range []Params{LightScryptParams, testArgon2idParams} {
+		t.Run(params.KDF+"/"+params.Cipher, func(t *testing.T) {
+			data, err := EncryptKey(account, "password", params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := DecryptKey(data, "password")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.PublicKey != account.PublicKey || !got.PrivateKey.Equal(account.PrivateKey) {
+				t.Fatal("decrypted account differs")
+			}
+			if _, err := DecryptKey(data, "wrong"); !errors.Is(err, ErrDecrypt) {
+				t.Fatalf("err = %v, want ErrDecrypt", err)
+			}
+
+			// the public key is authenticated, swapping it must fail
+			keyFile := KeyFile{}
+			if err := json.Unmarshal(data, &keyFile); err != nil {
+				t.Fatal(err)
+			}
+			keyFile.PublicKey = types.NewAccount().PublicKey.ToBase58()
+			tampered, _ := json.Marshal(keyFile)
+			if _, err := DecryptKey(tampered, "password"); !errors.Is(err, ErrDecrypt) {
+				t.Fatalf("err = %v, want ErrDecrypt", err)
+			}
+
+			changed, err := ChangePassword(data, "password", "new")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := DecryptKey(changed, "password"); !errors.Is(err, ErrDecrypt) {
+				t.Fatalf("old password err = %v, want ErrDecrypt", err)
+			}
+			if got, err := DecryptKey(changed, "new"); err != nil || got.PublicKey != account.PublicKey {
+				t.Fatalf("new password decrypt err: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecryptKeyRejectsCostlyParams(t *testing.T) {
+	account := types.NewAccount()
+	tests := []struct {
+		name   string
+		params Params
+		modify func(*KDFParams)
+	}{
+		{"scrypt n", LightScryptParams, func(p *KDFParams) { p.N = 1 << 30 }},
+		{"scrypt n not a power of two", LightScryptParams, func(p *KDFParams) { p.N = 3000 }},
+		{"scrypt r*p", LightScryptParams, func(p *KDFParams) { p.R, p.P = 1<<10, 1<<10 }},
+		{"scrypt memory", LightScryptParams, func(p *KDFParams) { p.N, p.R, p.P = 1<<20, 16, 1 }},
+		{"argon2id memory", testArgon2idParams, func(p *KDFParams) { p.Memory = 1 << 22 }},
+		{"argon2id time", testArgon2idParams, func(p *KDFParams) { p.Time = 1 << 20 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncryptKey(account, "password", tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			keyFile := KeyFile{}
+			if err := json.Unmarshal(data, &keyFile); err != nil {
+				t.Fatal(err)
+			}
+			tt.modify(&keyFile.Crypto.KDFParams)
+			crafted, _ := json.Marshal(keyFile)
+			if _, err := DecryptKey(crafted, "password"); err == nil || errors.Is(err, ErrDecrypt) {
+				t.Fatalf("err = %v, want params err", err)
+			}
+
+			params := tt.params
+			tt.modify(&params.KDFParams)
+			if _, err := EncryptKey(account, "password", params); err == nil {
+				t.Fatal("want params err")
+			}
+		})
+	}
+}
+
+func TestKeyStore(t *testing.T) {
+	dir := t.TempDir()
+	ks, err := NewKeyStore(dir, WithParams(LightScryptParams))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := ks.Create("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported := types.NewAccount()
+	if err := ks.Import(imported, "other"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Import(imported, "other"); !errors.Is(err, ErrAccountExists) {
+		t.Fatalf("err = %v, want ErrAccountExists", err)
+	}
+	// files which are not key files are skipped
+	if err := os.WriteFile(filepath.Join(dir, "notes.json"), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	accounts, err := ks.Accounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("accounts = %d, want 2", len(accounts))
+	}
+	if accounts[0].ToBase58() > accounts[1].ToBase58() {
+		t.Fatal("accounts are not sorted")
+	}
+
+	info, err := os.Stat(filepath.Join(dir, created.ToBase58()+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("key file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if err := ks.ChangePassword(created, "password", "new"); err != nil {
+		t.Fatal(err)
+	}
+	account, err := ks.Unlock(created, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.PublicKey != created {
+		t.Fatalf("unlocked %s, want %s", account.PublicKey.ToBase58(), created.ToBase58())
+	}
+
+	if err := ks.Delete(imported.PublicKey, "wrong"); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("err = %v, want ErrDecrypt", err)
+	}
+	if err := ks.Delete(imported.PublicKey, "other"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Unlock(imported.PublicKey, "other"); !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("err = %v, want ErrAccountNotFound", err)
+	}
+}
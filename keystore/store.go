@@ -0,0 +1,178 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mr-tron/base58"
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+const keyFileExt = ".json"
+
+var (
+	ErrAccountNotFound = errors.New("account not found in keystore")
+	ErrAccountExists   = errors.New("account already exists in keystore")
+)
+
+// KeyStore keeps one key file per account in a directory, named by the account's
+// base58 public key
+type KeyStore struct {
+	dir    string
+	params Params
+	mu     sync.Mutex
+}
+
+// Option configures a KeyStore created by NewKeyStore
+type Option func(*KeyStore)
+
+// WithParams replaces StandardScryptParams for the keys written by the store
+func WithParams(params Params) Option {
+	return func(ks *KeyStore) {
+		ks.params = params
+	}
+}
+
+// NewKeyStore opens the keystore in dir, creating the directory if needed
+func NewKeyStore(dir string, opts ...Option) (*KeyStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	ks := &KeyStore{dir: dir, params: StandardScryptParams}
+	for _, opt := range opts {
+		opt(ks)
+	}
+	return ks, nil
+}
+
+// Accounts lists the public keys of the stored accounts sorted by their base58 form
+func (ks *KeyStore) Accounts() ([]common.PublicKey, error) {
+	entries, err := os.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, keyFileExt) {
+			continue
+		}
+		name = strings.TrimSuffix(name, keyFileExt)
+		if b, err := base58.Decode(name); err != nil || len(b) != common.PublicKeyLength {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	accounts := make([]common.PublicKey, 0, len(names))
+	for _, name := range names {
+		accounts = append(accounts, common.PublicKeyFromString(name))
+	}
+	return accounts, nil
+}
+
+// Has reports whether the keystore holds the account
+func (ks *KeyStore) Has(publicKey common.PublicKey) bool {
+	_, err := os.Stat(ks.path(publicKey))
+	return err == nil
+}
+
+// Create generates a new account and stores it encrypted with password
+func (ks *KeyStore) Create(password string) (common.PublicKey, error) {
+	account := types.NewAccount()
+	if err := ks.Import(account, password); err != nil {
+		return common.PublicKey{}, err
+	}
+	return account.PublicKey, nil
+}
+
+// Import stores an existing account encrypted with password
+func (ks *KeyStore) Import(account types.Account, password string) error {
+	data, err := EncryptKey(account, password, ks.params)
+	if err != nil {
+		return err
+	}
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if ks.Has(account.PublicKey) {
+		return ErrAccountExists
+	}
+	return ks.write(account.PublicKey, data)
+}
+
+// Unlock decrypts the stored account
+func (ks *KeyStore) Unlock(publicKey common.PublicKey, password string) (types.Account, error) {
+	data, err := ks.read(publicKey)
+	if err != nil {
+		return types.Account{}, err
+	}
+	return DecryptKey(data, password)
+}
+
+// ChangePassword re-encrypts the stored account with newPassword
+func (ks *KeyStore) ChangePassword(publicKey common.PublicKey, oldPassword, newPassword string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	data, err := ks.read(publicKey)
+	if err != nil {
+		return err
+	}
+	data, err = ChangePassword(data, oldPassword, newPassword)
+	if err != nil {
+		return err
+	}
+	return ks.write(publicKey, data)
+}
+
+// Delete removes the stored account, the password must unlock it
+func (ks *KeyStore) Delete(publicKey common.PublicKey, password string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if _, err := ks.Unlock(publicKey, password); err != nil {
+		return err
+	}
+	return os.Remove(ks.path(publicKey))
+}
+
+func (ks *KeyStore) path(publicKey common.PublicKey) string {
+	return filepath.Join(ks.dir, publicKey.ToBase58()+keyFileExt)
+}
+
+func (ks *KeyStore) read(publicKey common.PublicKey) ([]byte, error) {
+	data, err := os.ReadFile(ks.path(publicKey))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrAccountNotFound
+	}
+	return data, err
+}
+
+// write replaces the key file through a rename so that a crash never leaves it half written
+func (ks *KeyStore) write(publicKey common.PublicKey, data []byte) error {
+	tmp, err := os.CreateTemp(ks.dir, "."+publicKey.ToBase58()+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), ks.path(publicKey)); err != nil {
+		return fmt.Errorf("key file %s write err: %w", publicKey.ToBase58(), err)
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ type Event [8]byte
 var StakeManagerAccountLengthDefault = uint64(100000)
 var StackAccountLengthDefault = uint64(1000)
 var StackFeeAccountLengthDefault = uint64(17)
+var UnstakeAccountLengthDefault = uint64(88)
 
 var (
 	InstructionInitializeStack        Instruction
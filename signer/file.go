@@ -0,0 +1,40 @@
+package signer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+// FileSigner signs with a solana-keygen keypair file, a JSON array of the 64 secret key bytes.
+// The file is read again for every signature so the key is not kept in memory
+type FileSigner struct {
+	path      string
+	publicKey common.PublicKey
+}
+
+func NewFileSigner(path string) (*FileSigner, error) {
+	account, err := types.AccountFromKeygenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSigner{path: path, publicKey: account.PublicKey}, nil
+}
+
+func (s *FileSigner) PublicKey() common.PublicKey {
+	return s.publicKey
+}
+
+func (s *FileSigner) Sign(ctx context.Context, message []byte) (types.Signature, error) {
+	account, err := types.AccountFromKeygenFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	if account.PublicKey != s.publicKey {
+		return nil, fmt.Errorf("keypair file %s changed, public key %s want %s", s.path, account.PublicKey.ToBase58(), s.publicKey.ToBase58())
+	}
+	return ed25519.Sign(account.PrivateKey, message), nil
+}
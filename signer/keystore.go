@@ -0,0 +1,32 @@
+package signer
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/keystore"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+// KeystoreSigner signs with an account of an encrypted keystore. The key is decrypted once
+// by NewKeystoreSigner, which fails on a wrong password, and kept for the signer's lifetime
+type KeystoreSigner struct {
+	account types.Account
+}
+
+func NewKeystoreSigner(ks *keystore.KeyStore, publicKey common.PublicKey, password string) (*KeystoreSigner, error) {
+	account, err := ks.Unlock(publicKey, password)
+	if err != nil {
+		return nil, err
+	}
+	return &KeystoreSigner{account: account}, nil
+}
+
+func (s *KeystoreSigner) PublicKey() common.PublicKey {
+	return s.account.PublicKey
+}
+
+func (s *KeystoreSigner) Sign(ctx context.Context, message []byte) (types.Signature, error) {
+	return ed25519.Sign(s.account.PrivateKey, message), nil
+}
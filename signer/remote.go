@@ -0,0 +1,125 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mr-tron/base58"
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+// RemoteSignRequest is posted to the signing service
+type RemoteSignRequest struct {
+	PublicKey string `json:"publicKey"` // base58
+	Message   string `json:"message"`   // base64 of the serialized transaction message
+}
+
+// RemoteSignResponse is returned by the signing service, Error is set if it refuses to sign
+type RemoteSignResponse struct {
+	Signature string `json:"signature"` // base58
+	Error     string `json:"error,omitempty"`
+}
+
+// RemoteSigner asks a signing service over http to sign for publicKey. Signatures
+// returned by the service are verified before use
+type RemoteSigner struct {
+	endpoint  string
+	publicKey common.PublicKey
+	client    *http.Client
+	header    http.Header
+}
+
+type RemoteSignerOption func(*RemoteSigner)
+
+// WithHTTPClient sets the http client used to reach the signing service
+func WithHTTPClient(httpClient *http.Client) RemoteSignerOption {
+	return func(s *RemoteSigner) {
+		s.client = httpClient
+	}
+}
+
+// WithHeader sets headers sent with every sign request, e.g. authorization
+func WithHeader(header http.Header) RemoteSignerOption {
+	return func(s *RemoteSigner) {
+		s.header = header.Clone()
+	}
+}
+
+func NewRemoteSigner(endpoint string, publicKey common.PublicKey, opts ...RemoteSignerOption) *RemoteSigner {
+	s := &RemoteSigner{
+		endpoint:  endpoint,
+		publicKey: publicKey,
+		client:    http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *RemoteSigner) PublicKey() common.PublicKey {
+	return s.publicKey
+}
+
+func (s *RemoteSigner) Sign(ctx context.Context, message []byte) (types.Signature, error) {
+	body, err := json.Marshal(RemoteSignRequest{
+		PublicKey: s.publicKey.ToBase58(),
+		Message:   base64.StdEncoding.EncodeToString(message),
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	res := RemoteSignResponse{}
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("remote signer http status %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("remote signer response decode err: %s", err)
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("remote signer refused: %s", res.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer http status %d", resp.StatusCode)
+	}
+
+	signature, err := base58.Decode(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer signature decode err: %s", err)
+	}
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(s.publicKey.Bytes(), message, signature) {
+		return nil, fmt.Errorf("remote signer returned an invalid signature for %s", s.publicKey.ToBase58())
+	}
+	return signature, nil
+}
+
+var (
+	_ types.Signer = (*RemoteSigner)(nil)
+	_ types.Signer = (*FileSigner)(nil)
+)
@@ -0,0 +1,171 @@
+package signer_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/stafiprotocol/solana-go-sdk/keystore"
+	"github.com/stafiprotocol/solana-go-sdk/signer"
+	"github.com/stafiprotocol/solana-go-sdk/sysprog"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+// signService is a stub signing service holding account's key
+func signService(t *testing.T, account types.Account, token string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(signer.RemoteSignResponse{Error: "unauthorized"})
+			return
+		}
+		req := signer.RemoteSignRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			return
+		}
+		if req.PublicKey != account.PublicKey.ToBase58() {
+			json.NewEncoder(w).Encode(signer.RemoteSignResponse{Error: "unknown key"})
+			return
+		}
+		message, err := base64.StdEncoding.DecodeString(req.Message)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		json.NewEncoder(w).Encode(signer.RemoteSignResponse{Signature: base58.Encode(ed25519.Sign(account.PrivateKey, message))})
+	}))
+}
+
+func writeKeypairFile(t *testing.T, account types.Account) string {
+	numbers := make([]string, 0, len(account.PrivateKey))
+	for _, b := range account.PrivateKey {
+		numbers = append(numbers, fmt.Sprint(b))
+	}
+	path := filepath.Join(t.TempDir(), "id.json")
+	if err := os.WriteFile(path, []byte("["+strings.Join(numbers, ",")+"]"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCreateRawTransactionWithSigners(t *testing.T) {
+	feePayer := types.NewAccount()
+	remoteAccount := types.NewAccount()
+	fileAccount := types.NewAccount()
+	keystoreAccount := types.NewAccount()
+
+	server := signService(t, remoteAccount, "Bearer secret")
+	defer server.Close()
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret")
+	remote := signer.NewRemoteSigner(server.URL, remoteAccount.PublicKey, signer.WithHeader(header))
+
+	file, err := signer.NewFileSigner(writeKeypairFile(t, fileAccount))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.PublicKey() != fileAccount.PublicKey {
+		t.Fatalf("file signer public key = %s", file.PublicKey().ToBase58())
+	}
+
+	ks, err := keystore.NewKeyStore(t.TempDir(), keystore.WithParams(keystore.LightScryptParams))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Import(keystoreAccount, "password"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.NewKeystoreSigner(ks, keystoreAccount.PublicKey, "wrong"); !errors.Is(err, keystore.ErrDecrypt) {
+		t.Fatalf("err = %v, want ErrDecrypt", err)
+	}
+	encrypted, err := signer.NewKeystoreSigner(ks, keystoreAccount.PublicKey, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := types.NewAccount().PublicKey
+	rawTx, err := types.CreateRawTransactionContext(context.Background(), types.CreateRawTransactionParam{
+		Instructions: []types.Instruction{
+			sysprog.Transfer(remoteAccount.PublicKey, to, 1),
+			sysprog.Transfer(fileAccount.PublicKey, to, 1),
+			sysprog.Transfer(keystoreAccount.PublicKey, to, 1),
+		},
+		Signers:         []types.Account{feePayer},
+		ExtraSigners:    []types.Signer{remote, file, encrypted},
+		FeePayer:        feePayer.PublicKey,
+		RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := types.TransactionDeserialize(rawTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, signature := range tx.Signatures {
+		if !ed25519.Verify(tx.Message.Accounts[i].Bytes(), message, signature) {
+			t.Fatalf("signature #%d of %s does not verify", i, tx.Message.Accounts[i].ToBase58())
+		}
+	}
+}
+
+func TestRemoteSignerErrors(t *testing.T) {
+	account := types.NewAccount()
+	server := signService(t, account, "")
+	defer server.Close()
+
+	unknown := signer.NewRemoteSigner(server.URL, types.NewAccount().PublicKey)
+	if _, err := unknown.Sign(context.Background(), []byte("message")); err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Fatalf("err = %v, want a refusal", err)
+	}
+
+	// a service answering with another key's signature must not be trusted
+	liar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(signer.RemoteSignResponse{Signature: base58.Encode(ed25519.Sign(types.NewAccount().PrivateKey, []byte("message")))})
+	}))
+	defer liar.Close()
+	if _, err := signer.NewRemoteSigner(liar.URL, account.PublicKey).Sign(context.Background(), []byte("message")); err == nil {
+		t.Fatal("expected an invalid signature error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := signer.NewRemoteSigner(server.URL, account.PublicKey).Sign(ctx, []byte("message")); err == nil {
+		t.Fatal("expected a context error")
+	}
+}
+
+func TestFileSignerRejectsBadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "id.json")
+	for _, content := range []string{`"not an array"`, `[1,2,3]`, `[` + strings.Repeat("256,", 63) + `256]`} {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := signer.NewFileSigner(path); err == nil {
+			t.Fatalf("NewFileSigner(%s) should fail", content)
+		}
+	}
+
+	// a secret whose public half was tampered with
+	account := types.NewAccount()
+	account.PrivateKey[63] ^= 1
+	if _, err := signer.NewFileSigner(writeKeypairFile(t, account)); err == nil {
+		t.Fatal("expected a public key mismatch error")
+	}
+}
@@ -0,0 +1,24 @@
+package sysprog
+
+import (
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+type DurableNonceMessageParam struct {
+	Instructions   []types.Instruction
+	FeePayer       common.PublicKey
+	NonceAccount   common.PublicKey
+	NonceAuthority common.PublicKey
+	Nonce          common.PublicKey // the nonce stored in NonceAccount
+}
+
+// NewDurableNonceMessage builds a message which does not expire: the stored nonce takes the
+// place of the recent blockhash and the nonce is advanced by the first instruction, so the
+// nonce authority must sign it
+func NewDurableNonceMessage(param DurableNonceMessageParam) types.Message {
+	instructions := make([]types.Instruction, 0, len(param.Instructions)+1)
+	instructions = append(instructions, AdvanceNonceAccount(param.NonceAccount, param.NonceAuthority))
+	instructions = append(instructions, param.Instructions...)
+	return types.NewMessage(param.FeePayer, instructions, param.Nonce.ToBase58())
+}
@@ -0,0 +1,54 @@
+package sysprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+	"github.com/stafiprotocol/solana-go-sdk/types"
+)
+
+func TestUpgradeNonceAccount(t *testing.T) {
+	nonceAccount := common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi")
+	want := types.Instruction{
+		Accounts: []types.AccountMeta{
+			{PubKey: nonceAccount, IsSigner: false, IsWritable: true},
+		},
+		ProgramID: common.SystemProgramID,
+		Data:      []byte{12, 0, 0, 0},
+	}
+	if got := UpgradeNonceAccount(nonceAccount); !reflect.DeepEqual(got, want) {
+		t.Errorf("UpgradeNonceAccount() = %v, want %v", got, want)
+	}
+}
+
+func TestNewDurableNonceMessage(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	nonceAccount := common.PublicKeyFromString("DTA7FmUNYuQs2mScj2Lx8gQV63SEL1zGtzCSvPxtijbi")
+	nonce := common.PublicKeyFromString("9B5XszUGdMaxCZ7uSQhPzdks5ZQSmWxrmzCSvtJ6Ns6g")
+	to := common.PublicKeyFromString("GfNeBVNszjgfV7gae6G4FGMsUHUrnXoNV1Q8bNzsfHRv")
+
+	message := NewDurableNonceMessage(DurableNonceMessageParam{
+		Instructions:   []types.Instruction{Transfer(feePayer, to, 1)},
+		FeePayer:       feePayer,
+		NonceAccount:   nonceAccount,
+		NonceAuthority: feePayer,
+		Nonce:          nonce,
+	})
+	if message.RecentBlockHash != nonce.ToBase58() {
+		t.Fatalf("blockhash = %s, want %s", message.RecentBlockHash, nonce.ToBase58())
+	}
+	instructions, err := message.Decompile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instructions) != 2 {
+		t.Fatalf("instructions = %d, want 2", len(instructions))
+	}
+	// the authority is the fee payer here, so its meta is writable in the compiled message
+	advance := AdvanceNonceAccount(nonceAccount, feePayer)
+	if instructions[0].ProgramID != advance.ProgramID || !reflect.DeepEqual(instructions[0].Data, advance.Data) ||
+		instructions[0].Accounts[0].PubKey != nonceAccount {
+		t.Fatalf("first instruction = %v, want AdvanceNonceAccount", instructions[0])
+	}
+}
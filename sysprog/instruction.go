@@ -20,6 +20,7 @@ const (
 	InstructionAllocateWithSeed
 	InstructionAssignWithSeed
 	InstructionTransferWithSeed
+	InstructionUpgradeNonceAccount
 )
 
 func CreateAccount(fromAccount, newAccount, owner common.PublicKey, initLamports, accountSpace uint64) types.Instruction {
@@ -150,6 +151,27 @@ func AdvanceNonceAccount(noncePubkey, authPubkey common.PublicKey) types.Instruc
 	}
 }
 
+// UpgradeNonceAccount moves a legacy nonce account to the current nonce version,
+// its stored nonce is replaced with a durable nonce
+func UpgradeNonceAccount(noncePubkey common.PublicKey) types.Instruction {
+	data, err := common.SerializeData(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionUpgradeNonceAccount,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		Accounts: []types.AccountMeta{
+			{PubKey: noncePubkey, IsSigner: false, IsWritable: true},
+		},
+		ProgramID: common.SystemProgramID,
+		Data:      data,
+	}
+}
+
 func WithdrawNonceAccount(noncePubkey, authPubkey, toPubkey common.PublicKey, lamports uint64) types.Instruction {
 	data, err := common.SerializeData(struct {
 		Instruction Instruction
@@ -25,6 +25,22 @@ func FeeCalculatorDeserialize(data []byte) (FeeCalculator, error) {
 
 const NonceAccountSize = 80
 
+// NonceVersion is the layout version of a nonce account. Both versions share the same layout,
+// a current nonce is derived from a blockhash so it can't be used as one in a legacy way
+type NonceVersion = uint32
+
+const (
+	NonceVersionLegacy NonceVersion = iota
+	NonceVersionCurrent
+)
+
+type NonceState = uint32
+
+const (
+	NonceStateUninitialized NonceState = iota
+	NonceStateInitialized
+)
+
 type NonceAccount struct {
 	Version          uint32
 	State            uint32
@@ -45,6 +61,9 @@ func NonceAccountDeserialize(data []byte) (NonceAccount, error) {
 	if err != nil {
 		return NonceAccount{}, err
 	}
+	if version > NonceVersionCurrent {
+		return NonceAccount{}, fmt.Errorf("nonce account version %d unknown", version)
+	}
 	return NonceAccount{
 		Version:          version,
 		State:            state,
@@ -53,3 +72,13 @@ func NonceAccountDeserialize(data []byte) (NonceAccount, error) {
 		FeeCalculator:    feeCalculator,
 	}, nil
 }
+
+// IsInitialized reports whether the account holds a usable nonce
+func (a NonceAccount) IsInitialized() bool {
+	return a.State == NonceStateInitialized
+}
+
+// IsLegacy reports whether the account must be upgraded with UpgradeNonceAccount
+func (a NonceAccount) IsLegacy() bool {
+	return a.Version == NonceVersionLegacy
+}
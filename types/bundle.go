@@ -0,0 +1,92 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+)
+
+// UnsignedBundleVersion is the version of the bundle format written by MarshalUnsignedBundle
+const UnsignedBundleVersion = 1
+
+// UnsignedBundle carries a message and the signatures collected so far between offline
+// signers, it is plain json so it can be moved as a file or a qr code
+type UnsignedBundle struct {
+	Version int               `json:"version"`
+	Message string            `json:"message"` // base64 of the serialized message
+	Signers []BundleSignature `json:"signers"` // the required signers in message order
+}
+
+type BundleSignature struct {
+	PublicKey string `json:"publicKey"`           // base58
+	Signature string `json:"signature,omitempty"` // base58, empty until signed
+}
+
+// MarshalUnsignedBundle serializes the transaction with the signatures it holds so far
+func (tx *Transaction) MarshalUnsignedBundle() ([]byte, error) {
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	if int(tx.Message.Header.NumRequireSignatures) > len(tx.Message.Accounts) {
+		return nil, errors.New("message lacks signer accounts")
+	}
+
+	bundle := UnsignedBundle{
+		Version: UnsignedBundleVersion,
+		Message: base64.StdEncoding.EncodeToString(message),
+		Signers: make([]BundleSignature, 0, tx.Message.Header.NumRequireSignatures),
+	}
+	for i := 0; i < int(tx.Message.Header.NumRequireSignatures); i++ {
+		signature := BundleSignature{PublicKey: tx.Message.Accounts[i].ToBase58()}
+		if i < len(tx.Signatures) && !isEmptySignature(tx.Signatures[i]) {
+			signature.Signature = base58.Encode(tx.Signatures[i])
+		}
+		bundle.Signers = append(bundle.Signers, signature)
+	}
+	return json.Marshal(bundle)
+}
+
+// UnmarshalUnsignedBundle restores a transaction from a bundle, every signature it carries
+// is verified against the message
+func UnmarshalUnsignedBundle(data []byte) (Transaction, error) {
+	bundle := UnsignedBundle{}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return Transaction{}, fmt.Errorf("bundle decode err: %s", err)
+	}
+	if bundle.Version != UnsignedBundleVersion {
+		return Transaction{}, fmt.Errorf("unsupported bundle version %d", bundle.Version)
+	}
+	messageData, err := base64.StdEncoding.DecodeString(bundle.Message)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("bundle message decode err: %s", err)
+	}
+	message, err := MessageDeserialize(messageData)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if len(bundle.Signers) != int(message.Header.NumRequireSignatures) || len(message.Accounts) < len(bundle.Signers) {
+		return Transaction{}, fmt.Errorf("bundle has %d signers, message requires %d", len(bundle.Signers), message.Header.NumRequireSignatures)
+	}
+
+	tx := NewUnsignedTransaction(message)
+	for i, signer := range bundle.Signers {
+		if signer.PublicKey != message.Accounts[i].ToBase58() {
+			return Transaction{}, fmt.Errorf("bundle signer #%d %s does not match the message", i+1, signer.PublicKey)
+		}
+		if signer.Signature == "" {
+			continue
+		}
+		signature, err := base58.Decode(signer.Signature)
+		if err != nil {
+			return Transaction{}, fmt.Errorf("bundle signature of %s decode err: %s", signer.PublicKey, err)
+		}
+		if err := tx.AddSignature(message.Accounts[i], signature); err != nil {
+			return Transaction{}, err
+		}
+	}
+	return tx, nil
+}
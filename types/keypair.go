@@ -0,0 +1,89 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mr-tron/base58"
+)
+
+// AccountFromSecretKey checks that the 64 byte secret key, the seed followed by the
+// public key, is consistent and returns its account
+func AccountFromSecretKey(secretKey []byte) (Account, error) {
+	if len(secretKey) != ed25519.PrivateKeySize {
+		return Account{}, fmt.Errorf("secret key length %d err, want %d", len(secretKey), ed25519.PrivateKeySize)
+	}
+	privateKey := ed25519.NewKeyFromSeed(secretKey[:ed25519.SeedSize])
+	if !bytes.Equal(secretKey[ed25519.SeedSize:], privateKey.Public().(ed25519.PublicKey)) {
+		return Account{}, fmt.Errorf("secret key public half does not match the seed")
+	}
+	return AccountFromPrivateKeyBytes(privateKey), nil
+}
+
+// AccountFromKeygenJSON decodes the solana-keygen format, a JSON array of the 64 secret key bytes
+func AccountFromKeygenJSON(data []byte) (Account, error) {
+	var raw []int
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Account{}, fmt.Errorf("keygen json decode err: %s", err)
+	}
+	secretKey := make([]byte, 0, len(raw))
+	for _, n := range raw {
+		if n < 0 || n > 255 {
+			return Account{}, fmt.Errorf("keygen json holds a non byte value %d", n)
+		}
+		secretKey = append(secretKey, uint8(n))
+	}
+	return AccountFromSecretKey(secretKey)
+}
+
+// KeygenJSON encodes the account in the solana-keygen format
+func (a Account) KeygenJSON() ([]byte, error) {
+	if len(a.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("private key length %d err", len(a.PrivateKey))
+	}
+	// []byte would be encoded as a base64 string
+	numbers := make([]uint, 0, len(a.PrivateKey))
+	for _, b := range a.PrivateKey {
+		numbers = append(numbers, uint(b))
+	}
+	return json.Marshal(numbers)
+}
+
+// AccountFromKeygenFile reads a keypair file written by solana-keygen
+func AccountFromKeygenFile(path string) (Account, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Account{}, err
+	}
+	account, err := AccountFromKeygenJSON(content)
+	if err != nil {
+		return Account{}, fmt.Errorf("keypair file %s: %w", path, err)
+	}
+	return account, nil
+}
+
+// WriteKeygenFile writes the account in the solana-keygen format, readable by the owner only
+func (a Account) WriteKeygenFile(path string) error {
+	content, err := a.KeygenJSON()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0600)
+}
+
+// AccountFromBase58 decodes a base58 64 byte secret key, as exported by wallets like Phantom
+func AccountFromBase58(secretKey string) (Account, error) {
+	bts, err := base58.Decode(secretKey)
+	if err != nil {
+		return Account{}, fmt.Errorf("secret key base58 decode err: %s", err)
+	}
+	return AccountFromSecretKey(bts)
+}
+
+// Base58 encodes the 64 byte secret key in base58
+func (a Account) Base58() string {
+	return base58.Encode(a.PrivateKey)
+}
@@ -0,0 +1,156 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKeygenJSONRoundTrip(t *testing.T) {
+	account := NewAccount()
+	data, err := account.KeygenJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "[") {
+		t.Fatalf("keygen json = %s, want a byte array", data)
+	}
+	got, err := AccountFromKeygenJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PublicKey != account.PublicKey {
+		t.Fatalf("public key = %s, want %s", got.PublicKey.ToBase58(), account.PublicKey.ToBase58())
+	}
+
+	path := filepath.Join(t.TempDir(), "id.json")
+	if err := account.WriteKeygenFile(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err = AccountFromKeygenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PublicKey != account.PublicKey {
+		t.Fatalf("file public key = %s, want %s", got.PublicKey.ToBase58(), account.PublicKey.ToBase58())
+	}
+
+	got, err = AccountFromBase58(account.Base58())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PublicKey != account.PublicKey {
+		t.Fatalf("base58 public key = %s, want %s", got.PublicKey.ToBase58(), account.PublicKey.ToBase58())
+	}
+}
+
+func TestAccountFromSecretKeyErr(t *testing.T) {
+	account := NewAccount()
+	tampered := append([]byte{}, account.PrivateKey...)
+	tampered[63] ^= 1
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not json", data: "abc"},
+		{name: "short", data: "[1,2,3]"},
+		{name: "not a byte", data: "[256" + strings.Repeat(",0", 63) + "]"},
+		{name: "public key mismatch", data: jsonBytes(tampered)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := AccountFromKeygenJSON([]byte(tt.data)); err == nil {
+				t.Fatal("want err")
+			}
+		})
+	}
+}
+
+func jsonBytes(b []byte) string {
+	numbers := make([]int, 0, len(b))
+	for _, n := range b {
+		numbers = append(numbers, int(n))
+	}
+	data, _ := json.Marshal(numbers)
+	return string(data)
+}
+
+// test vector 1 of SLIP-0010 for ed25519
+func TestAccountFromSeed(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "m", want: "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{path: "m/0'", want: "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{path: "m/0'/1'/2'/2'/1000000000'", want: "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			account, err := AccountFromSeed(seed, tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(account.PrivateKey.Seed()); got != tt.want {
+				t.Fatalf("key = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	for _, path := range []string{"m/0", "44'/501'", "m/x'"} {
+		if _, err := AccountFromSeed(seed, path); err == nil {
+			t.Fatalf("path %s want err", path)
+		}
+	}
+}
+
+func TestAccountFromMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic(128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(strings.Fields(mnemonic)); n != 12 {
+		t.Fatalf("words = %d, want 12", n)
+	}
+	first, err := AccountFromMnemonic(mnemonic, "", SolanaDerivationPath(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := AccountFromMnemonic(mnemonic, "", SolanaDerivationPath(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withPassphrase, err := AccountFromMnemonic(mnemonic, "passphrase", SolanaDerivationPath(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.PublicKey == second.PublicKey || first.PublicKey == withPassphrase.PublicKey {
+		t.Fatal("derived accounts must differ")
+	}
+
+	// known answer, the seed is the BIP39 reference and the address the one shown by
+	// solana-keygen and Phantom for this mnemonic
+	valid := strings.Repeat("abandon ", 11) + "about"
+	seed, err := MnemonicToSeed(valid, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(seed); got != "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4" {
+		t.Fatalf("seed = %s", got)
+	}
+	account, err := AccountFromMnemonic(valid, "", SolanaDerivationPath(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := account.PublicKey.ToBase58(); got != "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk" {
+		t.Fatalf("address = %s, want HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk", got)
+	}
+	badChecksum := strings.TrimSpace(strings.Repeat("abandon ", 12))
+	if _, err := AccountFromMnemonic(badChecksum, "", SolanaDerivationPath(0, 0)); err == nil {
+		t.Fatal("want checksum err")
+	}
+}
@@ -23,6 +23,16 @@ type Message struct {
 	Instructions    []CompiledInstruction
 }
 
+// SerializedSize returns the length of the serialized message without serializing it
+func (m *Message) SerializedSize() int {
+	size := 3 + compactLen(len(m.Accounts)) + 32*len(m.Accounts) + 32 + compactLen(len(m.Instructions))
+	for _, instruction := range m.Instructions {
+		size += 1 + compactLen(len(instruction.Accounts)) + len(instruction.Accounts) +
+			compactLen(len(instruction.Data)) + len(instruction.Data)
+	}
+	return size
+}
+
 func (m *Message) Serialize() ([]byte, error) {
 	b := []byte{}
 	b = append(b, m.Header.NumRequireSignatures)
@@ -54,17 +64,100 @@ func (m *Message) Serialize() ([]byte, error) {
 	return b, nil
 }
 
-func (m *Message) DecompileInstructions() []Instruction {
+// Sanitize checks the message is well formed the way the runtime requires: the header is
+// consistent with the accounts, the keys are unique, every index is in range and no program
+// is the fee payer or writable. Indexes of a deserialized message can only be trusted after it
+func (m *Message) Sanitize() error {
+	return sanitizeMessage(m.Header, m.Accounts, len(m.Accounts), m.RecentBlockHash, m.Instructions, m.IsWritable)
+}
+
+// sanitizeMessage checks a message with numAccounts accounts in total, of which keys are static
+func sanitizeMessage(header MessageHeader, keys []common.PublicKey, numAccounts int, recentBlockHash string, instructions []CompiledInstruction, isWritable func(i int) bool) error {
+	if header.NumReadonlySignedAccounts >= header.NumRequireSignatures {
+		return fmt.Errorf("readonly signed accounts %d must be less than required signatures %d", header.NumReadonlySignedAccounts, header.NumRequireSignatures)
+	}
+	if int(header.NumRequireSignatures)+int(header.NumReadonlyUnsignedAccounts) > len(keys) {
+		return fmt.Errorf("header requires %d signed and %d readonly unsigned accounts, message has %d", header.NumRequireSignatures, header.NumReadonlyUnsignedAccounts, len(keys))
+	}
+	if numAccounts > 256 {
+		return fmt.Errorf("too many accounts, %d", numAccounts)
+	}
+	seen := make(map[common.PublicKey]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			return fmt.Errorf("duplicate account %s", key.ToBase58())
+		}
+		seen[key] = true
+	}
+	blockHash, err := base58.Decode(recentBlockHash)
+	if err != nil || len(blockHash) != 32 {
+		return errors.New("recent block hash is not 32 bytes")
+	}
+
+	for i, instruction := range instructions {
+		if instruction.ProgramIDIndex <= 0 || instruction.ProgramIDIndex >= len(keys) {
+			return fmt.Errorf("instruction #%d program id index %d out of range", i+1, instruction.ProgramIDIndex)
+		}
+		if isWritable(instruction.ProgramIDIndex) {
+			return fmt.Errorf("instruction #%d program %s is writable", i+1, keys[instruction.ProgramIDIndex].ToBase58())
+		}
+		for _, idx := range instruction.Accounts {
+			if idx < 0 || idx >= numAccounts {
+				return fmt.Errorf("instruction #%d account index %d out of range", i+1, idx)
+			}
+		}
+	}
+	return nil
+}
+
+// IsSigner reports whether the account at index i signs the message
+func (m *Message) IsSigner(i int) bool {
+	return i >= 0 && i < int(m.Header.NumRequireSignatures) && i < len(m.Accounts)
+}
+
+// IsWritable reports whether the account at index i is writable. Signed accounts come first,
+// writable before readonly, then unsigned accounts, again writable before readonly
+func (m *Message) IsWritable(i int) bool {
+	if i < 0 || i >= len(m.Accounts) {
+		return false
+	}
+	numSigners := int(m.Header.NumRequireSignatures)
+	if i < numSigners {
+		return i < numSigners-int(m.Header.NumReadonlySignedAccounts)
+	}
+	return i < len(m.Accounts)-int(m.Header.NumReadonlyUnsignedAccounts)
+}
+
+// AccountMetas returns the signer and writable flags of every account of the message
+func (m *Message) AccountMetas() []AccountMeta {
+	metas := make([]AccountMeta, 0, len(m.Accounts))
+	for i, account := range m.Accounts {
+		metas = append(metas, AccountMeta{
+			PubKey:     account,
+			IsSigner:   m.IsSigner(i),
+			IsWritable: m.IsWritable(i),
+		})
+	}
+	return metas
+}
+
+// Decompile returns the instructions of the message with the account flags of the message,
+// NewMessage(m.Accounts[0], instructions, m.RecentBlockHash) compiles them back to m
+func (m *Message) Decompile() ([]Instruction, error) {
 	instructions := make([]Instruction, 0, len(m.Instructions))
-	for _, cins := range m.Instructions {
+	for i, cins := range m.Instructions {
+		if cins.ProgramIDIndex < 0 || cins.ProgramIDIndex >= len(m.Accounts) {
+			return nil, fmt.Errorf("instruction #%d program id index %d out of range", i+1, cins.ProgramIDIndex)
+		}
 		accounts := make([]AccountMeta, 0, len(cins.Accounts))
-		for i := 0; i < len(cins.Accounts); i++ {
+		for _, idx := range cins.Accounts {
+			if idx < 0 || idx >= len(m.Accounts) {
+				return nil, fmt.Errorf("instruction #%d account index %d out of range", i+1, idx)
+			}
 			accounts = append(accounts, AccountMeta{
-				PubKey:   m.Accounts[cins.Accounts[i]],
-				IsSigner: cins.Accounts[i] < int(m.Header.NumRequireSignatures),
-				IsWritable: cins.Accounts[i] <= int(m.Header.NumRequireSignatures-m.Header.NumReadonlySignedAccounts) ||
-					(cins.Accounts[i] >= int(m.Header.NumRequireSignatures) &&
-						cins.Accounts[i] <= len(cins.Accounts)-int(m.Header.NumReadonlyUnsignedAccounts)),
+				PubKey:     m.Accounts[idx],
+				IsSigner:   m.IsSigner(idx),
+				IsWritable: m.IsWritable(idx),
 			})
 		}
 		instructions = append(instructions, Instruction{
@@ -73,85 +166,103 @@ func (m *Message) DecompileInstructions() []Instruction {
 			Data:      cins.Data,
 		})
 	}
+	return instructions, nil
+}
+
+// DecompileInstructions is Decompile for trusted messages, it returns nil if an index is out of range.
+//
+// Deprecated: use Decompile.
+func (m *Message) DecompileInstructions() []Instruction {
+	instructions, err := m.Decompile()
+	if err != nil {
+		return nil
+	}
 	return instructions
 }
 
 func MessageDeserialize(messageData []byte) (Message, error) {
-	var numRequireSignatures, numReadonlySignedAccounts, numReadonlyUnsignedAccounts uint8
-	var t uint64
-	var err error
-	list := []*uint8{&numRequireSignatures, &numReadonlySignedAccounts, &numReadonlyUnsignedAccounts}
-	for i := 0; i < len(list); i++ {
-		t, err = parseUvarint(&messageData)
-		if t > 255 {
-			return Message{}, fmt.Errorf("message header #%d parse error: %v", i+1, err)
-		}
-		*list[i] = uint8(t)
+	if IsVersionedMessage(messageData) {
+		return Message{}, errors.New("message is versioned, use MessageV0Deserialize")
 	}
+	header, accounts, blockHash, instructions, err := parseMessageBody(&messageData)
+	if err != nil {
+		return Message{}, err
+	}
+	if len(messageData) != 0 {
+		return Message{}, fmt.Errorf("message has %d trailing bytes", len(messageData))
+	}
+	return Message{
+		Header:          header,
+		Accounts:        accounts,
+		RecentBlockHash: blockHash,
+		Instructions:    instructions,
+	}, nil
+}
 
-	accountCount, err := parseUvarint(&messageData)
-	if len(messageData) < int(accountCount)*32 {
-		return Message{}, errors.New("parse account error")
+// parseMessageBody parses the part shared by legacy and v0 messages, from the header to the
+// instructions. It checks every length against the remaining data so it never panics
+func parseMessageBody(messageData *[]byte) (MessageHeader, []common.PublicKey, string, []CompiledInstruction, error) {
+	header, err := readBytes(messageData, 3)
+	if err != nil {
+		return MessageHeader{}, nil, "", nil, fmt.Errorf("parse message header error: %v", err)
+	}
+
+	accountCount, err := parseUvarint(messageData)
+	if err != nil {
+		return MessageHeader{}, nil, "", nil, fmt.Errorf("parse account count error: %v", err)
+	}
+	if accountCount > uint64(len(*messageData))/32 {
+		return MessageHeader{}, nil, "", nil, errors.New("parse account error")
 	}
 	accounts := make([]common.PublicKey, 0, accountCount)
 	for i := 0; i < int(accountCount); i++ {
-		accounts = append(accounts, common.PublicKeyFromBytes(messageData[:32]))
-		messageData = messageData[32:]
+		accounts = append(accounts, common.PublicKeyFromBytes((*messageData)[:32]))
+		*messageData = (*messageData)[32:]
 	}
 
-	if len(messageData) < 32 {
-		return Message{}, errors.New("parse blockhash error")
+	blockHash, err := readBytes(messageData, 32)
+	if err != nil {
+		return MessageHeader{}, nil, "", nil, errors.New("parse blockhash error")
 	}
-	blockHash := base58.Encode(messageData[:32])
-	messageData = messageData[32:]
 
-	instructionCount, err := parseUvarint(&messageData)
+	instructionCount, err := parseUvarint(messageData)
 	if err != nil {
-		return Message{}, fmt.Errorf("parse instruction count error: %v", err)
+		return MessageHeader{}, nil, "", nil, fmt.Errorf("parse instruction count error: %v", err)
+	}
+	// every instruction takes at least 3 bytes
+	if instructionCount > uint64(len(*messageData))/3 {
+		return MessageHeader{}, nil, "", nil, errors.New("parse instruction count error: too large")
 	}
-
 	instructions := make([]CompiledInstruction, 0, instructionCount)
 	for i := 0; i < int(instructionCount); i++ {
-		programID, err := parseUvarint(&messageData)
+		programID, err := readBytes(messageData, 1)
 		if err != nil {
-			return Message{}, fmt.Errorf("parse instruction #%d programID error: %v", i+1, err)
+			return MessageHeader{}, nil, "", nil, fmt.Errorf("parse instruction #%d programID error: %v", i+1, err)
 		}
-		accountCount, err := parseUvarint(&messageData)
+		accountIdx, err := readCompactBytes(messageData)
 		if err != nil {
-			return Message{}, fmt.Errorf("parse instruction #%d account count error: %v", i+1, err)
-		}
-		accounts := make([]int, 0, accountCount)
-		for j := 0; j < int(accountCount); j++ {
-			accountIdx, err := parseUvarint(&messageData)
-			if err != nil {
-				return Message{}, fmt.Errorf("parse instruction #%d account #%d idx error: %v", i+1, j+1, err)
-			}
-			accounts = append(accounts, int(accountIdx))
+			return MessageHeader{}, nil, "", nil, fmt.Errorf("parse instruction #%d accounts error: %v", i+1, err)
 		}
-		dataLen, err := parseUvarint(&messageData)
+		data, err := readCompactBytes(messageData)
 		if err != nil {
-			return Message{}, fmt.Errorf("parse instruction #%d data length error: %v", i+1, err)
+			return MessageHeader{}, nil, "", nil, fmt.Errorf("parse instruction #%d data error: %v", i+1, err)
+		}
+		accounts := make([]int, 0, len(accountIdx))
+		for _, idx := range accountIdx {
+			accounts = append(accounts, int(idx))
 		}
-		var data []byte
-		data, messageData = messageData[:dataLen], messageData[dataLen:]
-
 		instructions = append(instructions, CompiledInstruction{
-			ProgramIDIndex: int(programID),
+			ProgramIDIndex: int(programID[0]),
 			Accounts:       accounts,
 			Data:           data,
 		})
 	}
 
-	return Message{
-		Header: MessageHeader{
-			NumRequireSignatures:        numRequireSignatures,
-			NumReadonlySignedAccounts:   numReadonlySignedAccounts,
-			NumReadonlyUnsignedAccounts: numReadonlyUnsignedAccounts,
-		},
-		Accounts:        accounts,
-		RecentBlockHash: blockHash,
-		Instructions:    instructions,
-	}, nil
+	return MessageHeader{
+		NumRequireSignatures:        header[0],
+		NumReadonlySignedAccounts:   header[1],
+		NumReadonlyUnsignedAccounts: header[2],
+	}, accounts, base58.Encode(blockHash), instructions, nil
 }
 
 func MustMessageDeserialize(messageData []byte) Message {
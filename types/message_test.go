@@ -109,6 +109,42 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "instruction touching a subset of the accounts",
+			fields: fields{
+				Header: MessageHeader{
+					NumRequireSignatures:        2,
+					NumReadonlySignedAccounts:   1,
+					NumReadonlyUnsignedAccounts: 2,
+				},
+				Accounts: []common.PublicKey{
+					common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+					common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"),
+					common.PublicKeyFromString("8Vaso6eE1pWktDHwy2qQBB1fhjmBgwzhoXQKe1sxtFjn"),
+					common.SysVarClockPubkey,
+					common.SystemProgramID,
+				},
+				RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+				Instructions: []CompiledInstruction{
+					{
+						ProgramIDIndex: 4,
+						Accounts:       []int{2, 1, 3},
+						Data:           []byte{1},
+					},
+				},
+			},
+			want: []Instruction{
+				{
+					Accounts: []AccountMeta{
+						{PubKey: common.PublicKeyFromString("8Vaso6eE1pWktDHwy2qQBB1fhjmBgwzhoXQKe1sxtFjn"), IsSigner: false, IsWritable: true},
+						{PubKey: common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b"), IsSigner: true, IsWritable: false},
+						{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+					},
+					ProgramID: common.SystemProgramID,
+					Data:      []byte{1},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -124,3 +160,79 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 		})
 	}
 }
+
+func TestMessage_DecompileRoundTrip(t *testing.T) {
+	feePayer := NewAccount().PublicKey
+	signer := NewAccount().PublicKey
+	readonlySigner := NewAccount().PublicKey
+	writable := NewAccount().PublicKey
+	readonly := NewAccount().PublicKey
+	program := NewAccount().PublicKey
+
+	tests := []struct {
+		name         string
+		instructions []Instruction
+	}{
+		{
+			name: "mixed flags across instructions",
+			instructions: []Instruction{
+				{
+					ProgramID: common.SystemProgramID,
+					Accounts: []AccountMeta{
+						{PubKey: signer, IsSigner: true, IsWritable: true},
+						{PubKey: readonly, IsSigner: false, IsWritable: false},
+					},
+					Data: []byte{1},
+				},
+				{
+					ProgramID: program,
+					Accounts: []AccountMeta{
+						{PubKey: readonlySigner, IsSigner: true, IsWritable: false},
+						{PubKey: writable, IsSigner: false, IsWritable: true},
+						{PubKey: readonly, IsSigner: false, IsWritable: false},
+					},
+					Data: []byte{2, 3},
+				},
+			},
+		},
+		{
+			name: "account promoted by a later instruction",
+			instructions: []Instruction{
+				{
+					ProgramID: program,
+					Accounts:  []AccountMeta{{PubKey: writable, IsSigner: false, IsWritable: false}},
+				},
+				{
+					ProgramID: program,
+					Accounts:  []AccountMeta{{PubKey: writable, IsSigner: false, IsWritable: true}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := NewMessage(feePayer, tt.instructions, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+			if err := message.Sanitize(); err != nil {
+				t.Fatal(err)
+			}
+			instructions, err := message.Decompile()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := NewMessage(message.Accounts[0], instructions, message.RecentBlockHash)
+			if !reflect.DeepEqual(got, message) {
+				t.Fatalf("round trip = %+v, want %+v", got, message)
+			}
+			for i, meta := range message.AccountMetas() {
+				if meta.PubKey != message.Accounts[i] || meta.IsSigner != message.IsSigner(i) || meta.IsWritable != message.IsWritable(i) {
+					t.Fatalf("AccountMetas()[%d] = %+v", i, meta)
+				}
+			}
+		})
+	}
+
+	bad := Message{Accounts: []common.PublicKey{feePayer}, Instructions: []CompiledInstruction{{ProgramIDIndex: 1}}}
+	if _, err := bad.Decompile(); err == nil {
+		t.Fatal("an out of range program index must fail")
+	}
+}
@@ -0,0 +1,299 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+// MessageVersionPrefix is set on the first byte of a versioned message, the
+// remaining 7 bits hold the version number
+const MessageVersionPrefix = byte(0x80)
+
+// MessageAddressTableLookup loads accounts of a message from an address lookup table
+type MessageAddressTableLookup struct {
+	AccountKey      common.PublicKey
+	WritableIndexes []uint8
+	ReadonlyIndexes []uint8
+}
+
+// AddressLookupTableAccount is the key and the stored addresses of an address lookup table,
+// it is used to compile a v0 message
+type AddressLookupTableAccount struct {
+	Key       common.PublicKey
+	Addresses []common.PublicKey
+}
+
+// MessageV0 is a version 0 message. Accounts only holds the static account keys, the
+// accounts loaded from lookup tables follow them in the order writable then readonly
+type MessageV0 struct {
+	Header              MessageHeader
+	Accounts            []common.PublicKey
+	RecentBlockHash     string
+	Instructions        []CompiledInstruction
+	AddressTableLookups []MessageAddressTableLookup
+}
+
+// IsVersionedMessage reports whether the serialized message carries a version prefix
+func IsVersionedMessage(messageData []byte) bool {
+	return len(messageData) > 0 && messageData[0]&MessageVersionPrefix != 0
+}
+
+func (m *MessageV0) Serialize() ([]byte, error) {
+	b := []byte{MessageVersionPrefix}
+	b = append(b, m.Header.NumRequireSignatures)
+	b = append(b, m.Header.NumReadonlySignedAccounts)
+	b = append(b, m.Header.NumReadonlyUnsignedAccounts)
+
+	b = append(b, common.UintToVarLenBytes(uint64(len(m.Accounts)))...)
+	for _, key := range m.Accounts {
+		b = append(b, key[:]...)
+	}
+
+	blockHash, err := base58.Decode(m.RecentBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockHash) != 32 {
+		return nil, errors.New("recent block hash length error")
+	}
+	b = append(b, blockHash...)
+
+	b = append(b, common.UintToVarLenBytes(uint64(len(m.Instructions)))...)
+	for _, instruction := range m.Instructions {
+		b = append(b, byte(instruction.ProgramIDIndex))
+		b = append(b, common.UintToVarLenBytes(uint64(len(instruction.Accounts)))...)
+		for _, accountIdx := range instruction.Accounts {
+			b = append(b, byte(accountIdx))
+		}
+
+		b = append(b, common.UintToVarLenBytes(uint64(len(instruction.Data)))...)
+		b = append(b, instruction.Data...)
+	}
+
+	b = append(b, common.UintToVarLenBytes(uint64(len(m.AddressTableLookups)))...)
+	for _, lookup := range m.AddressTableLookups {
+		b = append(b, lookup.AccountKey[:]...)
+		b = append(b, common.UintToVarLenBytes(uint64(len(lookup.WritableIndexes)))...)
+		b = append(b, lookup.WritableIndexes...)
+		b = append(b, common.UintToVarLenBytes(uint64(len(lookup.ReadonlyIndexes)))...)
+		b = append(b, lookup.ReadonlyIndexes...)
+	}
+	return b, nil
+}
+
+// Sanitize checks the message like Message.Sanitize, lookups must load at least one account
+// and programs must be static accounts
+func (m *MessageV0) Sanitize() error {
+	for i, lookup := range m.AddressTableLookups {
+		if len(lookup.WritableIndexes)+len(lookup.ReadonlyIndexes) == 0 {
+			return fmt.Errorf("address table lookup #%d loads no account", i+1)
+		}
+	}
+	static := Message{Header: m.Header, Accounts: m.Accounts}
+	return sanitizeMessage(m.Header, m.Accounts, len(m.Accounts)+m.NumLookupAccounts(), m.RecentBlockHash, m.Instructions, static.IsWritable)
+}
+
+// NumLookupAccounts returns the number of accounts loaded from the lookup tables
+func (m *MessageV0) NumLookupAccounts() int {
+	n := 0
+	for _, lookup := range m.AddressTableLookups {
+		n += len(lookup.WritableIndexes) + len(lookup.ReadonlyIndexes)
+	}
+	return n
+}
+
+// ResolveAccounts returns the full account list of the message, the static keys followed by
+// the writable and then the readonly accounts loaded from the given lookup tables
+func (m *MessageV0) ResolveAccounts(tables []AddressLookupTableAccount) ([]common.PublicKey, error) {
+	tableMap := make(map[common.PublicKey][]common.PublicKey, len(tables))
+	for _, table := range tables {
+		tableMap[table.Key] = table.Addresses
+	}
+
+	writable := []common.PublicKey{}
+	readonly := []common.PublicKey{}
+	for _, lookup := range m.AddressTableLookups {
+		addresses, exist := tableMap[lookup.AccountKey]
+		if !exist {
+			return nil, fmt.Errorf("lack lookup table %s", lookup.AccountKey.ToBase58())
+		}
+		for _, idx := range lookup.WritableIndexes {
+			if int(idx) >= len(addresses) {
+				return nil, fmt.Errorf("lookup table %s index %d out of range", lookup.AccountKey.ToBase58(), idx)
+			}
+			writable = append(writable, addresses[idx])
+		}
+		for _, idx := range lookup.ReadonlyIndexes {
+			if int(idx) >= len(addresses) {
+				return nil, fmt.Errorf("lookup table %s index %d out of range", lookup.AccountKey.ToBase58(), idx)
+			}
+			readonly = append(readonly, addresses[idx])
+		}
+	}
+
+	accounts := make([]common.PublicKey, 0, len(m.Accounts)+len(writable)+len(readonly))
+	accounts = append(accounts, m.Accounts...)
+	accounts = append(accounts, writable...)
+	accounts = append(accounts, readonly...)
+	return accounts, nil
+}
+
+// NewMessageV0 compiles the instructions like NewMessage, then moves every account which is
+// neither a signer nor an invoked program into the first given lookup table that holds it
+func NewMessageV0(feePayer common.PublicKey, instructions []Instruction, recentBlockHash string, lookupTables []AddressLookupTableAccount) (MessageV0, error) {
+	legacy := NewMessage(feePayer, instructions, recentBlockHash)
+
+	invoked := map[int]bool{}
+	for _, instruction := range legacy.Instructions {
+		invoked[instruction.ProgramIDIndex] = true
+	}
+
+	type location struct {
+		table int
+		index uint8
+	}
+	findInTables := func(key common.PublicKey) (location, bool) {
+		for t, table := range lookupTables {
+			for i, address := range table.Addresses {
+				if i > 255 {
+					break
+				}
+				if address == key {
+					return location{table: t, index: uint8(i)}, true
+				}
+			}
+		}
+		return location{}, false
+	}
+
+	lookups := make([]MessageAddressTableLookup, len(lookupTables))
+	for t, table := range lookupTables {
+		lookups[t].AccountKey = table.Key
+	}
+	writableLoaded := make([][]common.PublicKey, len(lookupTables))
+	readonlyLoaded := make([][]common.PublicKey, len(lookupTables))
+
+	staticAccounts := []common.PublicKey{}
+	numReadonlyUnsigned := 0
+	for i, account := range legacy.Accounts {
+		writable := legacy.IsWritable(i)
+		if i >= int(legacy.Header.NumRequireSignatures) && !invoked[i] {
+			if loc, ok := findInTables(account); ok {
+				if writable {
+					lookups[loc.table].WritableIndexes = append(lookups[loc.table].WritableIndexes, loc.index)
+					writableLoaded[loc.table] = append(writableLoaded[loc.table], account)
+				} else {
+					lookups[loc.table].ReadonlyIndexes = append(lookups[loc.table].ReadonlyIndexes, loc.index)
+					readonlyLoaded[loc.table] = append(readonlyLoaded[loc.table], account)
+				}
+				continue
+			}
+		}
+		if i >= int(legacy.Header.NumRequireSignatures) && !writable {
+			numReadonlyUnsigned++
+		}
+		staticAccounts = append(staticAccounts, account)
+	}
+
+	accounts := append([]common.PublicKey{}, staticAccounts...)
+	addressTableLookups := []MessageAddressTableLookup{}
+	for t := range lookups {
+		accounts = append(accounts, writableLoaded[t]...)
+	}
+	for t := range lookups {
+		accounts = append(accounts, readonlyLoaded[t]...)
+		if len(lookups[t].WritableIndexes)+len(lookups[t].ReadonlyIndexes) > 0 {
+			addressTableLookups = append(addressTableLookups, lookups[t])
+		}
+	}
+	if len(accounts) > 256 {
+		return MessageV0{}, fmt.Errorf("too many accounts, %d", len(accounts))
+	}
+
+	publicKeyToIdx := map[common.PublicKey]int{}
+	for idx, publicKey := range accounts {
+		publicKeyToIdx[publicKey] = idx
+	}
+	compiledInstructions := make([]CompiledInstruction, 0, len(legacy.Instructions))
+	for _, instruction := range legacy.Instructions {
+		accountIdx := make([]int, 0, len(instruction.Accounts))
+		for _, idx := range instruction.Accounts {
+			accountIdx = append(accountIdx, publicKeyToIdx[legacy.Accounts[idx]])
+		}
+		compiledInstructions = append(compiledInstructions, CompiledInstruction{
+			ProgramIDIndex: publicKeyToIdx[legacy.Accounts[instruction.ProgramIDIndex]],
+			Accounts:       accountIdx,
+			Data:           instruction.Data,
+		})
+	}
+
+	return MessageV0{
+		Header: MessageHeader{
+			NumRequireSignatures:        legacy.Header.NumRequireSignatures,
+			NumReadonlySignedAccounts:   legacy.Header.NumReadonlySignedAccounts,
+			NumReadonlyUnsignedAccounts: uint8(numReadonlyUnsigned),
+		},
+		Accounts:            staticAccounts,
+		RecentBlockHash:     recentBlockHash,
+		Instructions:        compiledInstructions,
+		AddressTableLookups: addressTableLookups,
+	}, nil
+}
+
+func MessageV0Deserialize(messageData []byte) (MessageV0, error) {
+	if !IsVersionedMessage(messageData) {
+		return MessageV0{}, errors.New("message is not versioned")
+	}
+	if version := messageData[0] &^ MessageVersionPrefix; version != 0 {
+		return MessageV0{}, fmt.Errorf("unsupported message version %d", version)
+	}
+	messageData = messageData[1:]
+
+	header, accounts, blockHash, instructions, err := parseMessageBody(&messageData)
+	if err != nil {
+		return MessageV0{}, err
+	}
+
+	lookupCount, err := parseUvarint(&messageData)
+	if err != nil {
+		return MessageV0{}, fmt.Errorf("parse address table lookup count error: %v", err)
+	}
+	if lookupCount > uint64(len(messageData))/32 {
+		return MessageV0{}, errors.New("parse address table lookup count error: too large")
+	}
+	lookups := make([]MessageAddressTableLookup, 0, lookupCount)
+	for i := 0; i < int(lookupCount); i++ {
+		key, err := readBytes(&messageData, 32)
+		if err != nil {
+			return MessageV0{}, fmt.Errorf("parse address table lookup #%d key error: %v", i+1, err)
+		}
+		writable, err := readCompactBytes(&messageData)
+		if err != nil {
+			return MessageV0{}, fmt.Errorf("parse address table lookup #%d writable indexes error: %v", i+1, err)
+		}
+		readonly, err := readCompactBytes(&messageData)
+		if err != nil {
+			return MessageV0{}, fmt.Errorf("parse address table lookup #%d readonly indexes error: %v", i+1, err)
+		}
+		lookups = append(lookups, MessageAddressTableLookup{
+			AccountKey:      common.PublicKeyFromBytes(key),
+			WritableIndexes: writable,
+			ReadonlyIndexes: readonly,
+		})
+	}
+
+	if len(messageData) != 0 {
+		return MessageV0{}, fmt.Errorf("message has %d trailing bytes", len(messageData))
+	}
+
+	return MessageV0{
+		Header:              header,
+		Accounts:            accounts,
+		RecentBlockHash:     blockHash,
+		Instructions:        instructions,
+		AddressTableLookups: lookups,
+	}, nil
+}
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"crypto/ed25519"
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+func TestNewMessageV0(t *testing.T) {
+	feePayer := AccountFromPrivateKeyBytes([]byte{220, 190, 97, 243, 86, 180, 6, 192, 121, 120, 30, 246, 134, 81, 46, 27, 181, 181, 148, 200, 182, 184, 13, 124, 51, 186, 141, 11, 125, 116, 9, 203, 206, 211, 135, 230, 195, 111, 87, 254, 147, 239, 143, 81, 110, 159, 49, 140, 109, 137, 224, 197, 24, 49, 223, 61, 123, 8, 78, 109, 110, 136, 228, 240})
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	readonly := common.PublicKeyFromString("SysvarC1ock11111111111111111111111111111111")
+	table := AddressLookupTableAccount{
+		Key:       common.PublicKeyFromString("8Vaso6eE1pWktDHwy2qQBB1fhjmBgwzhoXQKe1sxtFjn"),
+		Addresses: []common.PublicKey{readonly, common.SystemProgramID, to},
+	}
+	instructions := []Instruction{
+		{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer.PublicKey, IsSigner: true, IsWritable: true},
+				{PubKey: to, IsSigner: false, IsWritable: true},
+				{PubKey: readonly, IsSigner: false, IsWritable: false},
+			},
+			Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	message, err := NewMessageV0(feePayer.PublicKey, instructions, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5", []AddressLookupTableAccount{table})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the invoked program stays static even though the table holds it
+	if !reflect.DeepEqual(message.Accounts, []common.PublicKey{feePayer.PublicKey, common.SystemProgramID}) {
+		t.Fatalf("static accounts = %v", message.Accounts)
+	}
+	if message.Header != (MessageHeader{NumRequireSignatures: 1, NumReadonlySignedAccounts: 0, NumReadonlyUnsignedAccounts: 1}) {
+		t.Fatalf("header = %+v", message.Header)
+	}
+	wantLookups := []MessageAddressTableLookup{{AccountKey: table.Key, WritableIndexes: []uint8{2}, ReadonlyIndexes: []uint8{0}}}
+	if !reflect.DeepEqual(message.AddressTableLookups, wantLookups) {
+		t.Fatalf("lookups = %+v", message.AddressTableLookups)
+	}
+	wantInstruction := CompiledInstruction{ProgramIDIndex: 1, Accounts: []int{0, 2, 3}, Data: instructions[0].Data}
+	if !reflect.DeepEqual(message.Instructions[0], wantInstruction) {
+		t.Fatalf("instruction = %+v", message.Instructions[0])
+	}
+
+	accounts, err := message.ResolveAccounts([]AddressLookupTableAccount{table})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accounts, []common.PublicKey{feePayer.PublicKey, common.SystemProgramID, to, readonly}) {
+		t.Fatalf("resolved accounts = %v", accounts)
+	}
+
+	data, err := message.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[0] != MessageVersionPrefix {
+		t.Fatalf("version prefix = %x", data[0])
+	}
+	if _, err := MessageDeserialize(data); err == nil {
+		t.Fatal("legacy deserialize should reject a versioned message")
+	}
+	got, err := MessageV0Deserialize(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, message) {
+		t.Fatalf("MessageV0Deserialize() = %+v, want %+v", got, message)
+	}
+}
+
+func TestCreateRawTransactionV0(t *testing.T) {
+	feePayer := NewAccount()
+	to := NewAccount().PublicKey
+	table := AddressLookupTableAccount{Key: NewAccount().PublicKey, Addresses: []common.PublicKey{to}}
+
+	rawTx, err := CreateRawTransactionV0(CreateRawTransactionV0Param{
+		Instructions: []Instruction{{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer.PublicKey, IsSigner: true, IsWritable: true},
+				{PubKey: to, IsSigner: false, IsWritable: true},
+			},
+			Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+		}},
+		Signers:             []Account{feePayer},
+		FeePayer:            feePayer.PublicKey,
+		RecentBlockHash:     "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		AddressLookupTables: []AddressLookupTableAccount{table},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := TransactionV0Deserialize(rawTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(feePayer.PublicKey.Bytes()), message, tx.Signatures[0]) {
+		t.Fatal("signature does not verify")
+	}
+	if len(tx.Message.AddressTableLookups) != 1 || len(tx.Message.Accounts) != 2 {
+		t.Fatalf("unexpected message %+v", tx.Message)
+	}
+}
+
+func TestMessageV0DeserializeTruncated(t *testing.T) {
+	message := MessageV0{
+		Header:          MessageHeader{NumRequireSignatures: 1},
+		Accounts:        []common.PublicKey{common.SystemProgramID},
+		RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		Instructions:    []CompiledInstruction{{ProgramIDIndex: 0, Accounts: []int{0}, Data: []byte{1, 2, 3}}},
+		AddressTableLookups: []MessageAddressTableLookup{
+			{AccountKey: common.SystemProgramID, WritableIndexes: []uint8{1}, ReadonlyIndexes: []uint8{2}},
+		},
+	}
+	data, err := message.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(data); i++ {
+		if _, err := MessageV0Deserialize(data[:i]); err == nil {
+			t.Fatalf("MessageV0Deserialize(data[:%d]) should fail", i)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// hardenedOffset is added to the index of a hardened derivation path segment
+const hardenedOffset = uint32(0x80000000)
+
+// NewMnemonic returns a BIP39 english mnemonic of 12 (128 bits) to 24 (256 bits) words
+func NewMnemonic(bitSize int) (string, error) {
+	entropy, err := bip39.NewEntropy(bitSize)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// MnemonicToSeed checks the mnemonic checksum and returns the 64 byte BIP39 seed
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	return bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+}
+
+// SolanaDerivationPath returns m/44'/501'/account'/change', the path used by solana-keygen
+// and most wallets
+func SolanaDerivationPath(account, change uint32) string {
+	return fmt.Sprintf("m/44'/501'/%d'/%d'", account, change)
+}
+
+// AccountFromSeed derives the ed25519 key at path from a BIP39 seed following SLIP-0010,
+// every segment of the path must be hardened
+func AccountFromSeed(seed []byte, path string) (Account, error) {
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return Account{}, err
+	}
+
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+	for _, index := range indexes {
+		data := make([]byte, 0, 1+32+4)
+		data = append(data, 0)
+		data = append(data, key...)
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac = hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+	return AccountFromPrivateKeyBytes(ed25519.NewKeyFromSeed(key)), nil
+}
+
+// AccountFromMnemonic derives the key at path from the mnemonic and passphrase,
+// e.g. AccountFromMnemonic(mnemonic, "", SolanaDerivationPath(0, 0))
+func AccountFromMnemonic(mnemonic, passphrase, path string) (Account, error) {
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return Account{}, err
+	}
+	return AccountFromSeed(seed, path)
+}
+
+func parseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("derivation path %s must start with m", path)
+	}
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		if !strings.HasSuffix(segment, "'") {
+			return nil, errors.New("ed25519 only supports hardened derivation, path segments must end with '")
+		}
+		index, err := strconv.ParseUint(strings.TrimSuffix(segment, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("derivation path segment %s err: %s", segment, err)
+		}
+		indexes = append(indexes, uint32(index)+hardenedOffset)
+	}
+	return indexes, nil
+}
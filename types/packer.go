@@ -0,0 +1,112 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+type PackTransactionsParam struct {
+	// Groups are the ordered instructions to send, the instructions of a group always land in
+	// the same transaction. Use Ungrouped for instructions without such constraint
+	Groups [][]Instruction
+	// Prefix is prepended to every transaction, e.g. compute budget instructions
+	Prefix          []Instruction
+	Signers         []Account
+	ExtraSigners    []Signer
+	FeePayer        common.PublicKey
+	RecentBlockHash string
+	MaxSize         int // default: PacketDataSize
+}
+
+// Ungrouped puts every instruction in a group of its own
+func Ungrouped(instructions []Instruction) [][]Instruction {
+	groups := make([][]Instruction, 0, len(instructions))
+	for _, instruction := range instructions {
+		groups = append(groups, []Instruction{instruction})
+	}
+	return groups
+}
+
+// PackInstructions splits the ordered groups into the fewest transactions that each fit in
+// maxSize bytes, keeping the order and every group within one transaction
+func PackInstructions(feePayer common.PublicKey, prefix []Instruction, groups [][]Instruction, maxSize int) ([][]Instruction, error) {
+	if maxSize <= 0 {
+		maxSize = PacketDataSize
+	}
+	fits := func(instructions []Instruction) bool {
+		tx := Transaction{Message: NewMessage(feePayer, instructions, "")}
+		return tx.Size() <= maxSize
+	}
+
+	packed := [][]Instruction{}
+	current := append([]Instruction{}, prefix...)
+	numGroups := 0
+	for i, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		candidate := append(append([]Instruction{}, current...), group...)
+		if fits(candidate) {
+			current = candidate
+			numGroups++
+			continue
+		}
+		if numGroups == 0 {
+			return nil, fmt.Errorf("instruction group #%d does not fit in a transaction of %d bytes", i+1, maxSize)
+		}
+		packed = append(packed, current)
+		current = append(append([]Instruction{}, prefix...), group...)
+		numGroups = 1
+		if !fits(current) {
+			return nil, fmt.Errorf("instruction group #%d does not fit in a transaction of %d bytes", i+1, maxSize)
+		}
+	}
+	if numGroups > 0 {
+		packed = append(packed, current)
+	}
+	return packed, nil
+}
+
+// PackTransactions packs the groups with PackInstructions and signs every transaction with
+// the signers it requires
+func PackTransactions(ctx context.Context, param PackTransactionsParam) ([][]byte, error) {
+	if param.RecentBlockHash == "" {
+		return nil, errors.New("recent block hash is required")
+	}
+	packed, err := PackInstructions(param.FeePayer, param.Prefix, param.Groups, param.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := append(AccountSigners(param.Signers), param.ExtraSigners...)
+	rawTxs := make([][]byte, 0, len(packed))
+	for i, instructions := range packed {
+		tx := Transaction{Message: NewMessage(param.FeePayer, instructions, param.RecentBlockHash)}
+		if err := tx.Sign(ctx, requiredSigners(tx.Message, signers)); err != nil {
+			return nil, fmt.Errorf("transaction #%d: %w", i+1, err)
+		}
+		rawTx, err := tx.Serialize()
+		if err != nil {
+			return nil, fmt.Errorf("transaction #%d: %w", i+1, err)
+		}
+		rawTxs = append(rawTxs, rawTx)
+	}
+	return rawTxs, nil
+}
+
+// requiredSigners picks the signers the message requires
+func requiredSigners(message Message, signers []Signer) []Signer {
+	required := make([]Signer, 0, message.Header.NumRequireSignatures)
+	for _, signer := range signers {
+		for i := 0; i < int(message.Header.NumRequireSignatures) && i < len(message.Accounts); i++ {
+			if message.Accounts[i] == signer.PublicKey() {
+				required = append(required, signer)
+				break
+			}
+		}
+	}
+	return required
+}
@@ -0,0 +1,141 @@
+package types
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+func transferInstruction(from, to common.PublicKey) Instruction {
+	return Instruction{
+		ProgramID: common.SystemProgramID,
+		Accounts: []AccountMeta{
+			{PubKey: from, IsSigner: true, IsWritable: true},
+			{PubKey: to, IsSigner: false, IsWritable: true},
+		},
+		Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+	}
+}
+
+func TestTransactionSize(t *testing.T) {
+	feePayer := NewAccount()
+	instructions := []Instruction{}
+	for i := 0; i < 20; i++ {
+		instructions = append(instructions, transferInstruction(feePayer.PublicKey, NewAccount().PublicKey))
+	}
+	for n := 1; n <= len(instructions); n++ {
+		tx := Transaction{Message: NewMessage(feePayer.PublicKey, instructions[:n], "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")}
+		message, err := tx.Message.Serialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Message.SerializedSize() != len(message) {
+			t.Fatalf("SerializedSize() = %d, want %d", tx.Message.SerializedSize(), len(message))
+		}
+		if err := tx.Sign(context.Background(), []Signer{NewAccountSigner(feePayer)}); err != nil {
+			t.Fatal(err)
+		}
+		rawTx, err := tx.Serialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Size() != len(rawTx) {
+			t.Fatalf("Size() = %d, want %d", tx.Size(), len(rawTx))
+		}
+	}
+}
+
+func TestPackTransactions(t *testing.T) {
+	feePayer := NewAccount()
+	owner := NewAccount()
+	prefix := []Instruction{{ProgramID: common.PublicKeyFromString("ComputeBudget111111111111111111111111111111"), Data: []byte{2, 64, 13, 3, 0}}}
+
+	// single transfers, then groups of two transfers which must stay together
+	groups := [][]Instruction{}
+	for i := 0; i < 30; i++ {
+		groups = append(groups, []Instruction{transferInstruction(feePayer.PublicKey, NewAccount().PublicKey)})
+	}
+	for i := 0; i < 20; i++ {
+		groups = append(groups, []Instruction{
+			transferInstruction(owner.PublicKey, NewAccount().PublicKey),
+			transferInstruction(owner.PublicKey, NewAccount().PublicKey),
+		})
+	}
+
+	packed, err := PackInstructions(feePayer.PublicKey, prefix, groups, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packed) < 2 {
+		t.Fatalf("packed into %d transactions, the instructions do not fit in one", len(packed))
+	}
+
+	next := 0
+	for i, instructions := range packed {
+		if !reflect.DeepEqual(instructions[:len(prefix)], prefix) {
+			t.Fatalf("transaction #%d lacks the prefix", i)
+		}
+		rest := instructions[len(prefix):]
+		for len(rest) > 0 {
+			group := groups[next]
+			if len(rest) < len(group) || !reflect.DeepEqual(rest[:len(group)], group) {
+				t.Fatalf("transaction #%d breaks group #%d or its order", i, next)
+			}
+			rest = rest[len(group):]
+			next++
+		}
+		tx := Transaction{Message: NewMessage(feePayer.PublicKey, instructions, "")}
+		if tx.Size() > PacketDataSize {
+			t.Fatalf("transaction #%d size %d over the limit", i, tx.Size())
+		}
+		// fewest transactions: the following group would not have fit
+		if next < len(groups) {
+			tx := Transaction{Message: NewMessage(feePayer.PublicKey, append(append([]Instruction{}, instructions...), groups[next]...), "")}
+			if tx.Size() <= PacketDataSize {
+				t.Fatalf("transaction #%d could have taken group #%d", i, next)
+			}
+		}
+	}
+	if next != len(groups) {
+		t.Fatalf("packed %d groups, want %d", next, len(groups))
+	}
+
+	rawTxs, err := PackTransactions(context.Background(), PackTransactionsParam{
+		Groups:          groups,
+		Prefix:          prefix,
+		Signers:         []Account{feePayer, owner},
+		FeePayer:        feePayer.PublicKey,
+		RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rawTxs) != len(packed) {
+		t.Fatalf("transactions = %d, want %d", len(rawTxs), len(packed))
+	}
+	for i, rawTx := range rawTxs {
+		if len(rawTx) > PacketDataSize {
+			t.Fatalf("transaction #%d size %d over the limit", i, len(rawTx))
+		}
+		tx, err := TransactionDeserialize(rawTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Verify(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPackInstructionsOversizedGroup(t *testing.T) {
+	feePayer := NewAccount().PublicKey
+	group := []Instruction{}
+	for i := 0; i < 40; i++ {
+		group = append(group, transferInstruction(feePayer, NewAccount().PublicKey))
+	}
+	if _, err := PackInstructions(feePayer, nil, [][]Instruction{group}, 0); err == nil {
+		t.Fatal("a group over the size limit must fail")
+	}
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+// NewUnsignedTransaction returns a transaction of message with an empty signature slot
+// for every required signer, to be filled by PartialSign or AddSignature
+func NewUnsignedTransaction(message Message) Transaction {
+	tx := Transaction{Message: message}
+	tx.ensureSignatureSlots()
+	return tx
+}
+
+func (tx *Transaction) ensureSignatureSlots() {
+	for len(tx.Signatures) < int(tx.Message.Header.NumRequireSignatures) {
+		tx.Signatures = append(tx.Signatures, make(Signature, ed25519.SignatureSize))
+	}
+}
+
+// signerIndex returns the index of the required signer pubkey, -1 if it is not a required signer
+func (tx *Transaction) signerIndex(pubkey common.PublicKey) int {
+	for i := 0; i < int(tx.Message.Header.NumRequireSignatures) && i < len(tx.Message.Accounts); i++ {
+		if tx.Message.Accounts[i] == pubkey {
+			return i
+		}
+	}
+	return -1
+}
+
+// PartialSign fills the signature slots of the given signers, the other slots are kept
+func (tx *Transaction) PartialSign(ctx context.Context, signers []Signer) error {
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return err
+	}
+	tx.ensureSignatureSlots()
+	for _, signer := range signers {
+		idx := tx.signerIndex(signer.PublicKey())
+		if idx < 0 {
+			return fmt.Errorf("%s is not a required signer", signer.PublicKey().ToBase58())
+		}
+		signature, err := signer.Sign(ctx, message)
+		if err != nil {
+			return fmt.Errorf("%s sign err: %w", signer.PublicKey().ToBase58(), err)
+		}
+		if len(signature) != ed25519.SignatureSize || !ed25519.Verify(signer.PublicKey().Bytes(), message, signature) {
+			return fmt.Errorf("%s returned an invalid signature", signer.PublicKey().ToBase58())
+		}
+		tx.Signatures[idx] = signature
+	}
+	return nil
+}
+
+// AddSignature sets the signature of a required signer collected elsewhere, it is verified
+// against the message first
+func (tx *Transaction) AddSignature(pubkey common.PublicKey, signature Signature) error {
+	idx := tx.signerIndex(pubkey)
+	if idx < 0 {
+		return fmt.Errorf("%s is not a required signer", pubkey.ToBase58())
+	}
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return err
+	}
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(pubkey.Bytes(), message, signature) {
+		return fmt.Errorf("signature of %s does not verify", pubkey.ToBase58())
+	}
+	tx.ensureSignatureSlots()
+	tx.Signatures[idx] = signature
+	return nil
+}
+
+// MissingSigners returns the required signers whose signature slot is still empty
+func (tx *Transaction) MissingSigners() []common.PublicKey {
+	missing := []common.PublicKey{}
+	for i := 0; i < int(tx.Message.Header.NumRequireSignatures) && i < len(tx.Message.Accounts); i++ {
+		if i >= len(tx.Signatures) || isEmptySignature(tx.Signatures[i]) {
+			missing = append(missing, tx.Message.Accounts[i])
+		}
+	}
+	return missing
+}
+
+// IsFullySigned reports whether every required signer has signed
+func (tx *Transaction) IsFullySigned() bool {
+	return len(tx.MissingSigners()) == 0
+}
+
+func isEmptySignature(signature Signature) bool {
+	for _, b := range signature {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
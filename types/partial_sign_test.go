@@ -0,0 +1,157 @@
+package types
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+func TestPartialSignBundle(t *testing.T) {
+	feePayer := NewAccount()
+	admin := NewAccount()
+	owner := NewAccount()
+	instruction := Instruction{
+		ProgramID: common.SystemProgramID,
+		Accounts: []AccountMeta{
+			{PubKey: admin.PublicKey, IsSigner: true, IsWritable: false},
+			{PubKey: owner.PublicKey, IsSigner: true, IsWritable: false},
+		},
+		Data: []byte{1},
+	}
+	message := NewMessage(feePayer.PublicKey, []Instruction{instruction}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+
+	tx := NewUnsignedTransaction(message)
+	if got := tx.MissingSigners(); len(got) != 3 {
+		t.Fatalf("missing signers = %d, want 3", len(got))
+	}
+	if err := tx.PartialSign(context.Background(), []Signer{NewAccountSigner(feePayer)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.PartialSign(context.Background(), []Signer{NewAccountSigner(NewAccount())}); err == nil {
+		t.Fatal("a signer outside the message must be rejected")
+	}
+
+	// the bundle travels to the offline admin
+	bundle, err := tx.MarshalUnsignedBundle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline, err := UnmarshalUnsignedBundle(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(offline.MissingSigners(), tx.MissingSigners()) {
+		t.Fatalf("missing signers after round trip = %v", offline.MissingSigners())
+	}
+	if err := offline.PartialSign(context.Background(), []Signer{NewAccountSigner(admin)}); err != nil {
+		t.Fatal(err)
+	}
+	if bundle, err = offline.MarshalUnsignedBundle(); err != nil {
+		t.Fatal(err)
+	}
+
+	// and back, where the owner's signature was collected out of band
+	tx, err = UnmarshalUnsignedBundle(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messageData, err := tx.Message.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.AddSignature(owner.PublicKey, ed25519.Sign(admin.PrivateKey, messageData)); err == nil {
+		t.Fatal("a signature by another key must be rejected")
+	}
+	if err := tx.AddSignature(owner.PublicKey, ed25519.Sign(owner.PrivateKey, messageData)); err != nil {
+		t.Fatal(err)
+	}
+	if !tx.IsFullySigned() {
+		t.Fatalf("missing signers = %v", tx.MissingSigners())
+	}
+
+	rawTx, err := tx.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := CreateRawTransaction(CreateRawTransactionParam{
+		Instructions:    []Instruction{instruction},
+		Signers:         []Account{feePayer, admin, owner},
+		FeePayer:        feePayer.PublicKey,
+		RecentBlockHash: message.RecentBlockHash,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rawTx, want) {
+		t.Fatal("collected transaction differs from one signed in a single place")
+	}
+}
+
+func TestUnmarshalUnsignedBundleRejectsTampering(t *testing.T) {
+	feePayer := NewAccount()
+	message := NewMessage(feePayer.PublicKey, []Instruction{{ProgramID: common.SystemProgramID, Data: []byte{1}}}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+	tx := NewUnsignedTransaction(message)
+	if err := tx.PartialSign(context.Background(), []Signer{NewAccountSigner(feePayer)}); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := tx.MarshalUnsignedBundle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := NewMessage(feePayer.PublicKey, []Instruction{{ProgramID: common.SystemProgramID, Data: []byte{2}}}, message.RecentBlockHash)
+	otherTx := NewUnsignedTransaction(other)
+	otherBundle, err := otherTx.MarshalUnsignedBundle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// carry the signature of the first message over to the second
+	signed, unsigned := UnsignedBundle{}, UnsignedBundle{}
+	if err := json.Unmarshal(bundle, &signed); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(otherBundle, &unsigned); err != nil {
+		t.Fatal(err)
+	}
+	unsigned.Signers[0].Signature = signed.Signers[0].Signature
+	tampered, err := json.Marshal(unsigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UnmarshalUnsignedBundle(tampered); err == nil {
+		t.Fatal("a signature over another message must be rejected")
+	}
+}
+
+func TestSignFillsSignatureSlots(t *testing.T) {
+	feePayer := NewAccount()
+	owner := NewAccount()
+	instruction := Instruction{
+		ProgramID: common.SystemProgramID,
+		Accounts:  []AccountMeta{{PubKey: owner.PublicKey, IsSigner: true, IsWritable: false}},
+		Data:      []byte{1},
+	}
+	message := NewMessage(feePayer.PublicKey, []Instruction{instruction}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+	signers := []Signer{NewAccountSigner(feePayer), NewAccountSigner(owner)}
+
+	unsigned := NewUnsignedTransaction(message)
+	partial := NewUnsignedTransaction(message)
+	if err := partial.PartialSign(context.Background(), signers[:1]); err != nil {
+		t.Fatal(err)
+	}
+	for name, tx := range map[string]*Transaction{"unsigned": &unsigned, "partially signed": &partial} {
+		if err := tx.Sign(context.Background(), signers); err != nil {
+			t.Fatal(err)
+		}
+		if len(tx.Signatures) != 2 {
+			t.Fatalf("%s: signatures = %d, want 2", name, len(tx.Signatures))
+		}
+		if err := tx.Verify(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+}
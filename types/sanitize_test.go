@@ -0,0 +1,131 @@
+package types
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+func testTransaction(t testing.TB) (Transaction, []byte) {
+	feePayer := NewAccount()
+	to := NewAccount().PublicKey
+	message := NewMessage(feePayer.PublicKey, []Instruction{{
+		ProgramID: common.SystemProgramID,
+		Accounts: []AccountMeta{
+			{PubKey: feePayer.PublicKey, IsSigner: true, IsWritable: true},
+			{PubKey: to, IsSigner: false, IsWritable: true},
+		},
+		Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+	}}, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+	tx := Transaction{Message: message}
+	if err := tx.Sign(context.Background(), []Signer{NewAccountSigner(feePayer)}); err != nil {
+		t.Fatal(err)
+	}
+	rawTx, err := tx.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx, rawTx
+}
+
+func TestTransactionVerify(t *testing.T) {
+	_, rawTx := testTransaction(t)
+	tx, err := TransactionDeserialize(rawTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := append([]byte{}, rawTx...)
+	tampered[len(tampered)-1] ^= 1
+	tx, err = TransactionDeserialize(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err == nil {
+		t.Fatal("a tampered message must not verify")
+	}
+}
+
+func TestMessageSanitize(t *testing.T) {
+	valid, _ := testTransaction(t)
+	if err := valid.Message.Sanitize(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(m *Message)
+		want   string
+	}{
+		{"no signer", func(m *Message) { m.Header.NumRequireSignatures = 0 }, "readonly signed"},
+		{"readonly fee payer", func(m *Message) { m.Header.NumReadonlySignedAccounts = 1 }, "readonly signed"},
+		{"header exceeds accounts", func(m *Message) { m.Header.NumReadonlyUnsignedAccounts = 3 }, "header requires"},
+		{"duplicate key", func(m *Message) { m.Accounts[1] = m.Accounts[0] }, "duplicate account"},
+		{"program out of range", func(m *Message) { m.Instructions[0].ProgramIDIndex = 3 }, "program id index"},
+		{"fee payer as program", func(m *Message) { m.Instructions[0].ProgramIDIndex = 0 }, "program id index"},
+		{"writable program", func(m *Message) { m.Instructions[0].ProgramIDIndex = 1 }, "is writable"},
+		{"account out of range", func(m *Message) { m.Instructions[0].Accounts[1] = 3 }, "account index"},
+		{"bad blockhash", func(m *Message) { m.RecentBlockHash = "abc" }, "block hash"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, _ := testTransaction(t)
+			tt.modify(&tx.Message)
+			err := tx.Message.Sanitize()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Sanitize() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeserializeHostileInput(t *testing.T) {
+	_, rawTx := testTransaction(t)
+	for i := 0; i < len(rawTx); i++ {
+		if _, err := TransactionDeserialize(rawTx[:i]); err == nil {
+			t.Fatalf("TransactionDeserialize(rawTx[:%d]) should fail", i)
+		}
+	}
+
+	// a data length far beyond the message used to panic when slicing
+	message := rawTx[65:]
+	hostile := append([]byte{}, message[:len(message)-13]...)
+	hostile = append(hostile, 0xff, 0xff, 0x03)
+	if _, err := MessageDeserialize(hostile); err == nil {
+		t.Fatal("an oversized data length must fail")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		data := make([]byte, r.Intn(300))
+		r.Read(data)
+		if i%2 == 0 && len(data) > 0 {
+			copy(data, rawTx[:min(len(rawTx), len(data)/2)])
+		}
+		TransactionDeserialize(data)
+		MessageDeserialize(data)
+		MessageV0Deserialize(data)
+		TransactionV0Deserialize(data)
+	}
+}
+
+func FuzzTransactionDeserialize(f *testing.F) {
+	_, rawTx := testTransaction(f)
+	f.Add(rawTx)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tx, err := TransactionDeserialize(data)
+		if err != nil {
+			return
+		}
+		if tx.Message.Sanitize() == nil {
+			tx.Message.DecompileInstructions()
+		}
+		tx.Verify()
+	})
+}
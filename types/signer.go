@@ -0,0 +1,41 @@
+package types
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+// Signer signs transaction messages on behalf of an account, the private key may live
+// outside the process
+type Signer interface {
+	PublicKey() common.PublicKey
+	Sign(ctx context.Context, message []byte) (Signature, error)
+}
+
+type accountSigner struct {
+	account Account
+}
+
+// NewAccountSigner returns a Signer holding the private key of account in memory
+func NewAccountSigner(account Account) Signer {
+	return accountSigner{account: account}
+}
+
+func (s accountSigner) PublicKey() common.PublicKey {
+	return s.account.PublicKey
+}
+
+func (s accountSigner) Sign(ctx context.Context, message []byte) (Signature, error) {
+	return ed25519.Sign(s.account.PrivateKey, message), nil
+}
+
+// AccountSigners wraps accounts as in memory signers
+func AccountSigners(accounts []Account) []Signer {
+	signers := make([]Signer, 0, len(accounts))
+	for _, account := range accounts {
+		signers = append(signers, NewAccountSigner(account))
+	}
+	return signers
+}
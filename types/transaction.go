@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"crypto/ed25519"
 	"encoding/binary"
 	"errors"
@@ -16,30 +17,87 @@ type Transaction struct {
 	Message    Message
 }
 
-func (tx *Transaction) sign(accounts []Account) (*Transaction, error) {
-	accountMap := map[common.PublicKey]ed25519.PrivateKey{}
-	for _, account := range accounts {
-		accountMap[account.PublicKey] = account.PrivateKey
+// Sign sets the signatures of the message's required signers, every one of them
+// must be in signers. Signatures already in place, e.g. from PartialSign, are overwritten
+func (tx *Transaction) Sign(ctx context.Context, signers []Signer) error {
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return err
+	}
+	signatures, err := signMessage(ctx, message, tx.Message.Header, tx.Message.Accounts, signers)
+	if err != nil {
+		return err
+	}
+	// signMessage returns the signatures in signer index order
+	tx.ensureSignatureSlots()
+	copy(tx.Signatures, signatures)
+	return nil
+}
+
+// signMessage signs the serialized message with the signers of the header's required signer accounts
+func signMessage(ctx context.Context, message []byte, header MessageHeader, keys []common.PublicKey, signers []Signer) ([]Signature, error) {
+	signerMap := map[common.PublicKey]Signer{}
+	for _, signer := range signers {
+		signerMap[signer.PublicKey()] = signer
 	}
 
-	if int(tx.Message.Header.NumRequireSignatures) != len(accountMap) {
+	if int(header.NumRequireSignatures) != len(signerMap) {
 		return nil, fmt.Errorf("signer's num not match,require %d real is %d",
-			tx.Message.Header.NumRequireSignatures, len(accountMap))
+			header.NumRequireSignatures, len(signerMap))
+	}
+	if int(header.NumRequireSignatures) > len(keys) {
+		return nil, errors.New("message lacks signer accounts")
+	}
+
+	signatures := make([]Signature, 0, header.NumRequireSignatures)
+	for i := 0; i < int(header.NumRequireSignatures); i++ {
+		signer, exist := signerMap[keys[i]]
+		if !exist {
+			return nil, fmt.Errorf("lack %s's private key", keys[i].ToBase58())
+		}
+		signature, err := signer.Sign(ctx, message)
+		if err != nil {
+			return nil, fmt.Errorf("%s sign err: %w", keys[i].ToBase58(), err)
+		}
+		if len(signature) != ed25519.SignatureSize {
+			return nil, fmt.Errorf("%s signature length %d err", keys[i].ToBase58(), len(signature))
+		}
+		signatures = append(signatures, signature)
 	}
+	return signatures, nil
+}
 
+// Verify checks that there is one valid ed25519 signature of the message for every required signer
+func (tx *Transaction) Verify() error {
 	message, err := tx.Message.Serialize()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	for i := 0; i < int(tx.Message.Header.NumRequireSignatures); i++ {
-		privateKey, exist := accountMap[tx.Message.Accounts[i]]
-		if !exist {
-			return nil, fmt.Errorf("lack %s's private key", tx.Message.Accounts[i].ToBase58())
+	return verifySignatures(message, tx.Message.Header, tx.Message.Accounts, tx.Signatures)
+}
+
+func verifySignatures(message []byte, header MessageHeader, keys []common.PublicKey, signatures []Signature) error {
+	if len(signatures) != int(header.NumRequireSignatures) {
+		return fmt.Errorf("signature count %d not match, require %d", len(signatures), header.NumRequireSignatures)
+	}
+	if len(signatures) > len(keys) {
+		return errors.New("message lacks signer accounts")
+	}
+	for i, signature := range signatures {
+		if len(signature) != ed25519.SignatureSize || !ed25519.Verify(keys[i].Bytes(), message, signature) {
+			return fmt.Errorf("signature #%d of %s verification failed", i+1, keys[i].ToBase58())
 		}
-		signature := ed25519.Sign(privateKey, message)
-		tx.Signatures = append(tx.Signatures, signature)
 	}
-	return tx, nil
+	return nil
+}
+
+// PacketDataSize is the most bytes a serialized transaction can take
+const PacketDataSize = 1232
+
+// Size returns the length of the serialized transaction once every required signer has signed
+func (tx *Transaction) Size() int {
+	numSignatures := int(tx.Message.Header.NumRequireSignatures)
+	return compactLen(numSignatures) + 64*numSignatures + tx.Message.SerializedSize()
 }
 
 func (tx *Transaction) Serialize() ([]byte, error) {
@@ -71,17 +129,19 @@ func TransactionDeserialize(tx []byte) (Transaction, error) {
 	if signatureCount < 1 {
 		return Transaction{}, errors.New("signature count must be greater than or equal to 1")
 	}
-	if len(tx) < int(signatureCount)*64 {
+	if signatureCount > uint64(len(tx))/64 {
 		return Transaction{}, errors.New("parse signature error")
 	}
 	signatures := make([]Signature, 0, signatureCount)
 	for i := 0; i < int(signatureCount); i++ {
-		signatures = append(signatures, tx[:64])
+		signatures = append(signatures, tx[:64:64])
 		tx = tx[64:]
 	}
 
 	message, err := MessageDeserialize(tx)
-
+	if err != nil {
+		return Transaction{}, err
+	}
 	if uint64(message.Header.NumRequireSignatures) != signatureCount {
 		return Transaction{}, errors.New("numRequireSignatures is not equal to signatureCount")
 	}
@@ -103,11 +163,17 @@ func MustTransactionDeserialize(data []byte) Transaction {
 type CreateRawTransactionParam struct {
 	Instructions    []Instruction
 	Signers         []Account
+	ExtraSigners    []Signer // signers whose keys are not held in process, e.g. remote signers
 	FeePayer        common.PublicKey
 	RecentBlockHash string
 }
 
 func CreateRawTransaction(param CreateRawTransactionParam) ([]byte, error) {
+	return CreateRawTransactionContext(context.Background(), param)
+}
+
+// CreateRawTransactionContext is CreateRawTransaction with a context passed to the signers
+func CreateRawTransactionContext(ctx context.Context, param CreateRawTransactionParam) ([]byte, error) {
 	if param.RecentBlockHash == "" {
 		return nil, errors.New("recent block hash is required")
 	}
@@ -120,12 +186,12 @@ func CreateRawTransaction(param CreateRawTransactionParam) ([]byte, error) {
 		Message:    NewMessage(param.FeePayer, param.Instructions, param.RecentBlockHash),
 	}
 
-	signTx, err := tx.sign(param.Signers)
+	err := tx.Sign(ctx, append(AccountSigners(param.Signers), param.ExtraSigners...))
 	if err != nil {
 		return nil, err
 	}
 
-	return signTx.Serialize()
+	return tx.Serialize()
 }
 
 func parseUvarint(tx *[]byte) (uint64, error) {
@@ -140,6 +206,30 @@ func parseUvarint(tx *[]byte) (uint64, error) {
 	return u, nil
 }
 
+// compactLen returns the length of n encoded as compact-u16
+func compactLen(n int) int {
+	return len(common.UintToVarLenBytes(uint64(n)))
+}
+
+// readBytes consumes n bytes of data
+func readBytes(data *[]byte, n uint64) ([]byte, error) {
+	if uint64(len(*data)) < n {
+		return nil, errors.New("data is too short")
+	}
+	b := (*data)[:n:n]
+	*data = (*data)[n:]
+	return b, nil
+}
+
+// readCompactBytes consumes a compact-u16 length prefixed byte array
+func readCompactBytes(data *[]byte) ([]byte, error) {
+	n, err := parseUvarint(data)
+	if err != nil {
+		return nil, err
+	}
+	return readBytes(data, n)
+}
+
 func CreateTransaction(message Message, signaturePairs map[common.PublicKey]Signature) (Transaction, error) {
 	signatures := make([]Signature, 0, len(signaturePairs))
 	for i := 0; i < int(message.Header.NumRequireSignatures); i++ {
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stafiprotocol/solana-go-sdk/common"
+)
+
+// TransactionV0 is a transaction carrying a version 0 message
+type TransactionV0 struct {
+	Signatures []Signature
+	Message    MessageV0
+}
+
+// Sign appends the signatures of the message's required signers, every one of them
+// must be in signers
+func (tx *TransactionV0) Sign(ctx context.Context, signers []Signer) error {
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return err
+	}
+	signatures, err := signMessage(ctx, message, tx.Message.Header, tx.Message.Accounts, signers)
+	if err != nil {
+		return err
+	}
+	tx.Signatures = append(tx.Signatures, signatures...)
+	return nil
+}
+
+// Verify checks that there is one valid ed25519 signature of the message for every required signer
+func (tx *TransactionV0) Verify() error {
+	message, err := tx.Message.Serialize()
+	if err != nil {
+		return err
+	}
+	return verifySignatures(message, tx.Message.Header, tx.Message.Accounts, tx.Signatures)
+}
+
+func (tx *TransactionV0) Serialize() ([]byte, error) {
+	if len(tx.Signatures) == 0 || len(tx.Signatures) != int(tx.Message.Header.NumRequireSignatures) {
+		return nil, errors.New("Signature verification failed")
+	}
+
+	signatureCount := common.UintToVarLenBytes(uint64(len(tx.Signatures)))
+	messageData, err := tx.Message.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, 0, len(signatureCount)+len(tx.Signatures)*64+len(messageData))
+	output = append(output, signatureCount...)
+	for _, sig := range tx.Signatures {
+		output = append(output, sig...)
+	}
+	output = append(output, messageData...)
+
+	return output, nil
+}
+
+func TransactionV0Deserialize(tx []byte) (TransactionV0, error) {
+	signatureCount, err := parseUvarint(&tx)
+	if err != nil {
+		return TransactionV0{}, fmt.Errorf("parse signature count error: %v", err)
+	}
+	if signatureCount < 1 {
+		return TransactionV0{}, errors.New("signature count must be greater than or equal to 1")
+	}
+	if signatureCount > uint64(len(tx))/64 {
+		return TransactionV0{}, errors.New("parse signature error")
+	}
+	signatures := make([]Signature, 0, signatureCount)
+	for i := 0; i < int(signatureCount); i++ {
+		signatures = append(signatures, tx[:64:64])
+		tx = tx[64:]
+	}
+
+	message, err := MessageV0Deserialize(tx)
+	if err != nil {
+		return TransactionV0{}, err
+	}
+	if uint64(message.Header.NumRequireSignatures) != signatureCount {
+		return TransactionV0{}, errors.New("numRequireSignatures is not equal to signatureCount")
+	}
+
+	return TransactionV0{
+		Signatures: signatures,
+		Message:    message,
+	}, nil
+}
+
+type CreateRawTransactionV0Param struct {
+	Instructions        []Instruction
+	Signers             []Account
+	ExtraSigners        []Signer // signers whose keys are not held in process, e.g. remote signers
+	FeePayer            common.PublicKey
+	RecentBlockHash     string
+	AddressLookupTables []AddressLookupTableAccount
+}
+
+// CreateRawTransactionV0 compiles the instructions into a v0 message using the given
+// lookup tables, signs it and returns the serialized transaction
+func CreateRawTransactionV0(param CreateRawTransactionV0Param) ([]byte, error) {
+	return CreateRawTransactionV0Context(context.Background(), param)
+}
+
+// CreateRawTransactionV0Context is CreateRawTransactionV0 with a context passed to the signers
+func CreateRawTransactionV0Context(ctx context.Context, param CreateRawTransactionV0Param) ([]byte, error) {
+	if param.RecentBlockHash == "" {
+		return nil, errors.New("recent block hash is required")
+	}
+	if len(param.Instructions) < 1 {
+		return nil, errors.New("no instructions provided")
+	}
+
+	message, err := NewMessageV0(param.FeePayer, param.Instructions, param.RecentBlockHash, param.AddressLookupTables)
+	if err != nil {
+		return nil, err
+	}
+	tx := TransactionV0{
+		Signatures: []Signature{},
+		Message:    message,
+	}
+
+	err = tx.Sign(ctx, append(AccountSigners(param.Signers), param.ExtraSigners...))
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.Serialize()
+}
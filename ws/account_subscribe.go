@@ -0,0 +1,22 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+type AccountSubscribeConfig struct {
+	Commitment client.Commitment                   `json:"commitment,omitempty"`
+	Encoding   client.GetAccountInfoConfigEncoding `json:"encoding,omitempty"`
+}
+
+type AccountNotification struct {
+	Context client.Context                `json:"context"`
+	Value   client.GetAccountInfoResponse `json:"value"`
+}
+
+// AccountSubscribe notifies when the lamports or data of account change
+func (c *Client) AccountSubscribe(ctx context.Context, account string, cfg AccountSubscribeConfig) (*Subscription[AccountNotification], error) {
+	return subscribe[AccountNotification](ctx, c, "accountSubscribe", "accountUnsubscribe", []interface{}{account, cfg}, false)
+}
@@ -0,0 +1,424 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+const (
+	DevnetWSEndpoint  = "wss://api.devnet.solana.com"
+	TestnetWSEndpoint = "wss://api.testnet.solana.com"
+	MainnetWSEndpoint = "wss://api.mainnet-beta.solana.com"
+)
+
+var ErrClosed = errors.New("ws client closed")
+
+// errDisconnected fails requests in flight when the connection drops
+var errDisconnected = errors.New("ws disconnected")
+
+// pongWaitPings is the number of ping intervals a connection may stay silent, neither a
+// message nor a pong, before it is considered half open
+const pongWaitPings = 3
+
+type options struct {
+	header         http.Header
+	dialer         *websocket.Dialer
+	minBackoff     time.Duration
+	maxBackoff     time.Duration
+	pingInterval   time.Duration
+	updateCapacity int
+}
+
+// Option configures a Client created by Dial
+type Option func(*options)
+
+// WithHeader adds header to the websocket handshake, e.g. an Authorization header
+func WithHeader(header http.Header) Option {
+	return func(o *options) {
+		o.header = header
+	}
+}
+
+func WithDialer(dialer *websocket.Dialer) Option {
+	return func(o *options) {
+		o.dialer = dialer
+	}
+}
+
+// WithReconnectBackoff sets the wait between reconnect attempts, it doubles from min up to max
+func WithReconnectBackoff(min, max time.Duration) Option {
+	return func(o *options) {
+		o.minBackoff = min
+		o.maxBackoff = max
+	}
+}
+
+// WithPingInterval sets how often the connection is pinged, a connection silent for
+// pongWaitPings intervals is dropped and reconnected
+func WithPingInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pingInterval = interval
+	}
+}
+
+// WithUpdateCapacity sets the buffer of every Updates channel
+func WithUpdateCapacity(capacity int) Option {
+	return func(o *options) {
+		o.updateCapacity = capacity
+	}
+}
+
+// Client is a solana pubsub client. It reconnects when the connection drops and
+// resubscribes every active subscription on the new connection.
+type Client struct {
+	endpoint string
+	opts     options
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	nextID  uint64
+	pending map[uint64]*pendingRequest
+	subs    map[*subscription]struct{}
+	active  map[uint64]*subscription // by server subscription id
+	closed  bool
+
+	done chan struct{}
+}
+
+type rpcResponse struct {
+	result json.RawMessage
+	err    error
+}
+
+type pendingRequest struct {
+	ch chan rpcResponse
+	// onResult runs on the read goroutine before later messages are handled
+	onResult func(result json.RawMessage) error
+}
+
+type message struct {
+	ID     *uint64               `json:"id"`
+	Method string                `json:"method"`
+	Result json.RawMessage       `json:"result"`
+	Error  *client.ErrorResponse `json:"error"`
+	Params *notificationParams   `json:"params"`
+}
+
+type notificationParams struct {
+	Result       json.RawMessage `json:"result"`
+	Subscription uint64          `json:"subscription"`
+}
+
+// Dial connects to endpoint, e.g. MainnetWSEndpoint
+func Dial(ctx context.Context, endpoint string, opts ...Option) (*Client, error) {
+	o := options{
+		dialer:         websocket.DefaultDialer,
+		minBackoff:     500 * time.Millisecond,
+		maxBackoff:     30 * time.Second,
+		pingInterval:   20 * time.Second,
+		updateCapacity: 100,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := &Client{
+		endpoint: endpoint,
+		opts:     o,
+		pending:  make(map[uint64]*pendingRequest),
+		subs:     make(map[*subscription]struct{}),
+		active:   make(map[uint64]*subscription),
+		done:     make(chan struct{}),
+	}
+	conn, _, err := o.dialer.DialContext(ctx, endpoint, o.header)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	go c.run(conn)
+	go c.ping()
+	return c, nil
+}
+
+// Close ends every subscription and the connection
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	conn := c.conn
+	subs := make([]*subscription, 0, len(c.subs))
+	for sub := range c.subs {
+		subs = append(subs, sub)
+	}
+	c.subs = make(map[*subscription]struct{})
+	c.active = make(map[uint64]*subscription)
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.end(ErrClosed)
+	}
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+// run reads conn until it fails, then reconnects until the client is closed
+func (c *Client) run(conn *websocket.Conn) {
+	for {
+		c.read(conn)
+		c.disconnect(conn)
+
+		conn = c.reconnect()
+		if conn == nil {
+			return
+		}
+		go c.resubscribe()
+	}
+}
+
+// read dispatches the messages of conn until it fails. The read deadline is pushed back by
+// every message and pong, so a peer gone without closing the connection is detected.
+func (c *Client) read(conn *websocket.Conn) {
+	pongWait := pongWaitPings * c.opts.pingInterval
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		msg := message{}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		if msg.ID != nil {
+			c.resolve(*msg.ID, msg)
+			continue
+		}
+		if msg.Params != nil {
+			c.notify(msg.Params.Subscription, msg.Params.Result)
+		}
+	}
+}
+
+func (c *Client) resolve(id uint64, msg message) {
+	c.mu.Lock()
+	req, ok := c.pending[id]
+	delete(c.pending, id)
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	res := rpcResponse{result: msg.Result}
+	if msg.Error != nil {
+		res.err = &client.RPCError{Code: msg.Error.Code, Message: msg.Error.Message, Data: msg.Error.Data}
+	} else if req.onResult != nil {
+		res.err = req.onResult(msg.Result)
+	}
+	req.ch <- res
+}
+
+func (c *Client) notify(serverID uint64, result json.RawMessage) {
+	c.mu.Lock()
+	sub, ok := c.active[serverID]
+	if ok && sub.oneShot {
+		delete(c.active, serverID)
+		delete(c.subs, sub)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	// the response of an unsubscribe request is read by this goroutine, it must not wait for it
+	if err := sub.deliver(result); err != nil {
+		if serverID, isActive := c.cancel(sub, err); isActive {
+			go c.unsubscribe(sub, serverID)
+		}
+		return
+	}
+	if sub.oneShot {
+		sub.end(nil)
+	}
+}
+
+// disconnect fails the requests in flight, active subscriptions are kept for resubscribe
+func (c *Client) disconnect(conn *websocket.Conn) {
+	conn.Close()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, req := range c.pending {
+		req.ch <- rpcResponse{err: errDisconnected}
+		delete(c.pending, id)
+	}
+	c.active = make(map[uint64]*subscription)
+	if c.conn == conn {
+		c.conn = nil
+	}
+}
+
+func (c *Client) reconnect() *websocket.Conn {
+	backoff := c.opts.minBackoff
+	for {
+		select {
+		case <-c.done:
+			return nil
+		case <-time.After(backoff):
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		conn, _, err := c.opts.dialer.DialContext(ctx, c.endpoint, c.opts.header)
+		cancel()
+		if err == nil {
+			c.mu.Lock()
+			if c.closed {
+				c.mu.Unlock()
+				conn.Close()
+				return nil
+			}
+			c.conn = conn
+			c.mu.Unlock()
+			return conn
+		}
+		backoff *= 2
+		if backoff > c.opts.maxBackoff {
+			backoff = c.opts.maxBackoff
+		}
+	}
+}
+
+func (c *Client) resubscribe() {
+	c.mu.Lock()
+	subs := make([]*subscription, 0, len(c.subs))
+	for sub := range c.subs {
+		subs = append(subs, sub)
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		c.activate(sub)
+	}
+}
+
+// activate subscribes sub on the current connection. A rejected subscription is ended,
+// a dropped connection is left to the next resubscribe.
+func (c *Client) activate(sub *subscription) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err := c.subscribeRequest(ctx, sub)
+	var rpcErr *client.RPCError
+	if errors.As(err, &rpcErr) {
+		c.remove(sub)
+		sub.end(err)
+	}
+}
+
+// subscribeRequest sends the subscribe request of sub and marks it active under the returned
+// server id, before any notification that follows the response is dispatched
+func (c *Client) subscribeRequest(ctx context.Context, sub *subscription) error {
+	_, err := c.request(ctx, sub.method, sub.params, func(result json.RawMessage) error {
+		var serverID uint64
+		if err := json.Unmarshal(result, &serverID); err != nil {
+			return fmt.Errorf("subscription id err: %w", err)
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if _, ok := c.subs[sub]; ok {
+			sub.serverID = serverID
+			c.active[serverID] = sub
+		}
+		return nil
+	})
+	return err
+}
+
+// request sends a json rpc request on the current connection and waits for its response
+func (c *Client) request(ctx context.Context, method string, params []interface{}, onResult func(json.RawMessage) error) (json.RawMessage, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+	conn := c.conn
+	if conn == nil {
+		c.mu.Unlock()
+		return nil, errDisconnected
+	}
+	c.nextID++
+	id := c.nextID
+	ch := make(chan rpcResponse, 1)
+	c.pending[id] = &pendingRequest{ch: ch, onResult: onResult}
+	c.mu.Unlock()
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  method,
+		"params":  params,
+	})
+	if err == nil {
+		c.writeMu.Lock()
+		err = conn.WriteMessage(websocket.TextMessage, payload)
+		c.writeMu.Unlock()
+	}
+	if err != nil {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	select {
+	case res := <-ch:
+		return res.result, res.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	case <-c.done:
+		return nil, ErrClosed
+	}
+}
+
+func (c *Client) remove(sub *subscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.subs, sub)
+	if c.active[sub.serverID] == sub {
+		delete(c.active, sub.serverID)
+	}
+}
+
+func (c *Client) ping() {
+	ticker := time.NewTicker(c.opts.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
+		c.mu.Lock()
+		conn := c.conn
+		c.mu.Unlock()
+		if conn != nil {
+			conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package ws_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stafiprotocol/solana-go-sdk/ws"
+)
+
+type fakeNode struct {
+	connections int32
+	subID       int32
+}
+
+// ServeHTTP answers every subscribe request, sends one notification and then drops
+// the connection once, the second connection stays open
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	connection := atomic.AddInt32(&n.connections, 1)
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		req := struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}{}
+		json.Unmarshal(data, &req)
+		if strings.HasSuffix(req.Method, "Unsubscribe") {
+			conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":true}`, req.ID)))
+			continue
+		}
+		subID := atomic.AddInt32(&n.subID, 1)
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%d}`, req.ID, subID)))
+
+		var result string
+		switch req.Method {
+		case "accountSubscribe":
+			result = fmt.Sprintf(`{"context":{"slot":%d},"value":{"lamports":%d,"owner":"11111111111111111111111111111111","executable":false,"rentEpoch":0,"data":["","base64"]}}`, connection, connection)
+		case "signatureSubscribe":
+			result = `{"context":{"slot":5},"value":{"err":{"InstructionError":[0,{"Custom":1}]}}}`
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","method":"%s","params":{"result":%s,"subscription":%d}}`, strings.Replace(req.Method, "Subscribe", "Notification", 1), result, subID)))
+		if connection == 1 && req.Method == "accountSubscribe" {
+			return
+		}
+	}
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestAccountSubscribeResubscribes(t *testing.T) {
+	server := httptest.NewServer(&fakeNode{})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := ws.Dial(ctx, wsURL(server), ws.WithReconnectBackoff(10*time.Millisecond, 100*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	sub, err := c.AccountSubscribe(ctx, "11111111111111111111111111111111", ws.AccountSubscribeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for want := uint64(1); want <= 2; want++ {
+		select {
+		case update := <-sub.Updates:
+			if update.Value.Lamports != want || update.Context.Slot != want {
+				t.Fatalf("update = %+v, want lamports %d", update, want)
+			}
+		case <-ctx.Done():
+			t.Fatalf("wait update %d timeout", want)
+		}
+	}
+
+	sub.Unsubscribe()
+	if _, ok := <-sub.Updates; ok {
+		t.Fatal("updates should be closed")
+	}
+	if err := <-sub.Err; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignatureSubscribeOneShot(t *testing.T) {
+	server := httptest.NewServer(&fakeNode{connections: 1})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := ws.Dial(ctx, wsURL(server))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	sub, err := c.SignatureSubscribe(ctx, "sig", ws.SignatureSubscribeConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	update, ok := <-sub.Updates
+	if !ok {
+		t.Fatal("want an update")
+	}
+	if update.Value.Err == nil || *update.Value.Err.InstructionError.Custom != 1 {
+		t.Fatalf("update = %+v", update)
+	}
+	if _, ok := <-sub.Updates; ok {
+		t.Fatal("updates should be closed after the notification")
+	}
+}
+
+// burstNode answers a subscribe request with notifications, one per result, without
+// waiting for the client to consume them
+type burstNode struct {
+	results []string
+}
+
+func (n *burstNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		req := struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}{}
+		json.Unmarshal(data, &req)
+		if strings.HasSuffix(req.Method, "Unsubscribe") {
+			conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":true}`, req.ID)))
+			continue
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":1}`, req.ID)))
+		for _, result := range n.results {
+			conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","method":"slotNotification","params":{"result":%s,"subscription":1}}`, result)))
+		}
+	}
+}
+
+func TestSubscriptionEndsWithErr(t *testing.T) {
+	slot := `{"parent":1,"root":0,"slot":2}`
+	tests := []struct {
+		name    string
+		results []string
+		want    int // updates delivered before the subscription ends
+		wantErr func(error) bool
+	}{
+		{
+			name:    "slow consumer",
+			results: []string{slot, slot, slot},
+			want:    2,
+			wantErr: func(err error) bool { return errors.Is(err, ws.ErrSlowConsumer) },
+		},
+		{
+			name:    "decode err",
+			results: []string{slot, `"bad"`},
+			want:    1,
+			wantErr: func(err error) bool { return err != nil && !errors.Is(err, ws.ErrSlowConsumer) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&burstNode{results: tt.results})
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			c, err := ws.Dial(ctx, wsURL(server), ws.WithUpdateCapacity(2))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+
+			sub, err := c.SlotSubscribe(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case err := <-sub.Err:
+				if !tt.wantErr(err) {
+					t.Fatalf("err = %v", err)
+				}
+			case <-ctx.Done():
+				t.Fatal("wait err timeout")
+			}
+			got := 0
+			for range sub.Updates {
+				got++
+			}
+			if got != tt.want {
+				t.Fatalf("updates = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// silentNode stops reading its first connection after the subscribe response, so pings
+// are never answered while the connection stays open. Later connections answer normally.
+type silentNode struct {
+	connections int32
+	release     chan struct{}
+}
+
+func (n *silentNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	connection := atomic.AddInt32(&n.connections, 1)
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		req := struct {
+			ID uint64 `json:"id"`
+		}{}
+		json.Unmarshal(data, &req)
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%d}`, req.ID, connection)))
+		if connection == 1 {
+			<-n.release
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","method":"slotNotification","params":{"result":{"parent":1,"root":0,"slot":2},"subscription":%d}}`, connection)))
+	}
+}
+
+func TestReconnectOnSilentConnection(t *testing.T) {
+	node := &silentNode{release: make(chan struct{})}
+	server := httptest.NewServer(node)
+	defer server.Close()
+	defer close(node.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c, err := ws.Dial(ctx, wsURL(server), ws.WithPingInterval(20*time.Millisecond), ws.WithReconnectBackoff(10*time.Millisecond, 100*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	sub, err := c.SlotSubscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case update := <-sub.Updates:
+		if update.Slot != 2 {
+			t.Fatalf("update = %+v", update)
+		}
+	case <-ctx.Done():
+		t.Fatal("no update after the silent connection, it was not dropped")
+	}
+	if n := atomic.LoadInt32(&node.connections); n != 2 {
+		t.Fatalf("connections = %d, want 2", n)
+	}
+}
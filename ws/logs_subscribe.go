@@ -0,0 +1,53 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+// LogsFilter selects the transactions of logsSubscribe
+type LogsFilter struct {
+	all      string
+	mentions []string
+}
+
+// LogsFilterAll matches all transactions except simple vote transactions
+func LogsFilterAll() LogsFilter {
+	return LogsFilter{all: "all"}
+}
+
+func LogsFilterAllWithVotes() LogsFilter {
+	return LogsFilter{all: "allWithVotes"}
+}
+
+// LogsFilterMentions matches transactions mentioning address
+func LogsFilterMentions(address string) LogsFilter {
+	return LogsFilter{mentions: []string{address}}
+}
+
+func (f LogsFilter) MarshalJSON() ([]byte, error) {
+	if f.mentions != nil {
+		return json.Marshal(map[string][]string{"mentions": f.mentions})
+	}
+	return json.Marshal(f.all)
+}
+
+type LogsSubscribeConfig struct {
+	Commitment client.Commitment `json:"commitment,omitempty"`
+}
+
+type LogsNotification struct {
+	Context client.Context `json:"context"`
+	Value   struct {
+		Signature string                   `json:"signature"`
+		Err       *client.TransactionError `json:"err"`
+		Logs      []string                 `json:"logs"`
+	} `json:"value"`
+}
+
+// LogsSubscribe notifies the logs of transactions matching filter
+func (c *Client) LogsSubscribe(ctx context.Context, filter LogsFilter, cfg LogsSubscribeConfig) (*Subscription[LogsNotification], error) {
+	return subscribe[LogsNotification](ctx, c, "logsSubscribe", "logsUnsubscribe", []interface{}{filter, cfg}, false)
+}
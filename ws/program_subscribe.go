@@ -0,0 +1,25 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+// ProgramSubscribeConfig filters take the same values as client.GetProgramAccountsConfig, e.g.
+// map[string]interface{}{"memcmp": client.Memcmp{...}} and map[string]interface{}{"dataSize": 88}
+type ProgramSubscribeConfig struct {
+	Commitment client.Commitment                   `json:"commitment,omitempty"`
+	Encoding   client.GetAccountInfoConfigEncoding `json:"encoding,omitempty"`
+	Filters    []interface{}                       `json:"filters,omitempty"`
+}
+
+type ProgramNotification struct {
+	Context client.Context                    `json:"context"`
+	Value   client.GetProgramAccountsResponse `json:"value"`
+}
+
+// ProgramSubscribe notifies when an account owned by programId changes
+func (c *Client) ProgramSubscribe(ctx context.Context, programId string, cfg ProgramSubscribeConfig) (*Subscription[ProgramNotification], error) {
+	return subscribe[ProgramNotification](ctx, c, "programSubscribe", "programUnsubscribe", []interface{}{programId, cfg}, false)
+}
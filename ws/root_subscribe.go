@@ -0,0 +1,8 @@
+package ws
+
+import "context"
+
+// RootSubscribe notifies every new root slot set by the node
+func (c *Client) RootSubscribe(ctx context.Context) (*Subscription[uint64], error) {
+	return subscribe[uint64](ctx, c, "rootSubscribe", "rootUnsubscribe", []interface{}{}, false)
+}
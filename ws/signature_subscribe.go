@@ -0,0 +1,24 @@
+package ws
+
+import (
+	"context"
+
+	"github.com/stafiprotocol/solana-go-sdk/client"
+)
+
+type SignatureSubscribeConfig struct {
+	Commitment client.Commitment `json:"commitment,omitempty"`
+}
+
+type SignatureNotification struct {
+	Context client.Context `json:"context"`
+	Value   struct {
+		Err *client.TransactionError `json:"err"`
+	} `json:"value"`
+}
+
+// SignatureSubscribe notifies once when the transaction reaches the commitment, the
+// subscription ends after the notification
+func (c *Client) SignatureSubscribe(ctx context.Context, signature string, cfg SignatureSubscribeConfig) (*Subscription[SignatureNotification], error) {
+	return subscribe[SignatureNotification](ctx, c, "signatureSubscribe", "signatureUnsubscribe", []interface{}{signature, cfg}, true)
+}
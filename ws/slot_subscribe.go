@@ -0,0 +1,14 @@
+package ws
+
+import "context"
+
+type SlotNotification struct {
+	Parent uint64 `json:"parent"`
+	Root   uint64 `json:"root"`
+	Slot   uint64 `json:"slot"`
+}
+
+// SlotSubscribe notifies every slot processed by the node
+func (c *Client) SlotSubscribe(ctx context.Context) (*Subscription[SlotNotification], error) {
+	return subscribe[SlotNotification](ctx, c, "slotSubscribe", "slotUnsubscribe", []interface{}{}, false)
+}
@@ -0,0 +1,130 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSlowConsumer ends a subscription whose Updates buffer is full, notifications are
+// read by a single goroutine which never waits on a consumer
+var ErrSlowConsumer = errors.New("ws subscription updates buffer full")
+
+// Subscription delivers typed notifications on Updates. Updates is closed when the
+// subscription ends, the cause (nil after Unsubscribe or a one shot notification, ErrSlowConsumer
+// when Updates is not drained in time, or a decode error) is sent on Err.
+type Subscription[T any] struct {
+	Updates <-chan T
+	Err     <-chan error
+
+	client *Client
+	sub    *subscription
+}
+
+type subscription struct {
+	method      string
+	unsubMethod string
+	params      []interface{}
+	oneShot     bool // e.g. signatureSubscribe, ended by the server after the first notification
+	serverID    uint64
+
+	deliver func(result json.RawMessage) error
+	closeFn func()
+	errCh   chan error
+
+	mu   sync.Mutex
+	once sync.Once
+	done chan struct{}
+}
+
+// end stops delivery and closes Updates, it is safe to call more than once
+func (s *subscription) end(err error) {
+	s.once.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		s.closeFn()
+		s.mu.Unlock()
+		s.errCh <- err
+		close(s.errCh)
+	})
+}
+
+func subscribe[T any](ctx context.Context, c *Client, method, unsubMethod string, params []interface{}, oneShot bool) (*Subscription[T], error) {
+	updates := make(chan T, c.opts.updateCapacity)
+	sub := &subscription{
+		method:      method,
+		unsubMethod: unsubMethod,
+		params:      params,
+		oneShot:     oneShot,
+		errCh:       make(chan error, 1),
+		done:        make(chan struct{}),
+	}
+	sub.closeFn = func() { close(updates) }
+	sub.deliver = func(result json.RawMessage) error {
+		var update T
+		if err := json.Unmarshal(result, &update); err != nil {
+			return fmt.Errorf("%s notification decode err: %w", method, err)
+		}
+		sub.mu.Lock()
+		defer sub.mu.Unlock()
+		select {
+		case <-sub.done:
+			return nil
+		default:
+		}
+		select {
+		case updates <- update:
+			return nil
+		default:
+			return ErrSlowConsumer
+		}
+	}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+	c.subs[sub] = struct{}{}
+	c.mu.Unlock()
+	if err := c.subscribeRequest(ctx, sub); err != nil {
+		c.remove(sub)
+		return nil, err
+	}
+
+	return &Subscription[T]{
+		Updates: updates,
+		Err:     sub.errCh,
+		client:  c,
+		sub:     sub,
+	}, nil
+}
+
+// Unsubscribe ends the subscription and tells the server to stop sending notifications
+func (s *Subscription[T]) Unsubscribe() {
+	if serverID, isActive := s.client.cancel(s.sub, nil); isActive {
+		s.client.unsubscribe(s.sub, serverID)
+	}
+}
+
+// cancel removes sub and ends it with err, it reports the server id to unsubscribe
+// when sub is active on the current connection
+func (c *Client) cancel(sub *subscription, err error) (serverID uint64, isActive bool) {
+	c.mu.Lock()
+	serverID = sub.serverID
+	isActive = c.active[serverID] == sub
+	c.mu.Unlock()
+	c.remove(sub)
+	sub.end(err)
+	return serverID, isActive
+}
+
+// unsubscribe tells the server to stop sending the notifications of sub
+func (c *Client) unsubscribe(sub *subscription, serverID uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c.request(ctx, sub.unsubMethod, []interface{}{serverID}, nil)
+}